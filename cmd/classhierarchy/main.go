@@ -0,0 +1,97 @@
+// Generates class hierarchy files (in the format of the default library files like resources/data/javalang.csv)
+// by reading the class files of a JDK (lib/modules or rt.jar), jmod files, jar files or directories containing class files.
+//
+// Usage:
+//   classhierarchy -output <file.csv> [-packages java.lang,java.util] <input path> [<input path> ...]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"returntypes-langserver/common/code/java/classfile"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+)
+
+var outputPath string
+var packages string
+
+func main() {
+	flag.StringVar(&outputPath, "output", "", "the path of the csv file the class hierarchy is written to")
+	flag.StringVar(&packages, "packages", "", "a comma separated list of packages (including their sub packages) to include. If empty, all classes are included")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -output <file.csv> [-packages java.lang,java.util] <input path>...\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "An input path may be a JDK directory, a jimage file (lib/modules), a jar/jmod file or a directory containing class files. Compressed classes of jimage files are skipped.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log.SetLoggingToStdout(true)
+	if outputPath == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	records, err := readClassRecords(flag.Args(), splitPackages(packages))
+	if err != nil {
+		log.FatalError(err)
+	}
+	if err := csv.NewFileWriter(outputPath).WriteClassRecords(records); err != nil {
+		log.FatalError(err)
+	}
+	log.Info("Wrote %d classes to %s\n", len(records), outputPath)
+}
+
+// Reads the accessible classes of all input paths. If a class is contained in multiple inputs, the first occurrence is used.
+func readClassRecords(inputPaths []string, packages []string) ([]csv.Class, errors.Error) {
+	classes := make(map[string]csv.Class)
+	for _, path := range inputPaths {
+		log.Info("Read classes of %s ...\n", path)
+		headers, err := classfile.ReadClasses(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range classfile.ToClassRecords(headers) {
+			if _, exists := classes[record.ClassName]; !exists && isInPackages(record.ClassName, packages) {
+				classes[record.ClassName] = record
+			}
+		}
+	}
+
+	records := make([]csv.Class, 0, len(classes))
+	for _, record := range classes {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ClassName < records[j].ClassName
+	})
+	return records, nil
+}
+
+func splitPackages(value string) []string {
+	if value == "" {
+		return nil
+	}
+	splitted := strings.Split(value, ",")
+	for i := range splitted {
+		splitted[i] = strings.TrimSpace(splitted[i])
+	}
+	return splitted
+}
+
+func isInPackages(className string, packages []string) bool {
+	if len(packages) == 0 {
+		return true
+	}
+	for _, pkg := range packages {
+		if strings.HasPrefix(className, pkg+".") {
+			return true
+		}
+	}
+	return false
+}
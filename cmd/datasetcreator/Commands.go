@@ -0,0 +1,251 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/processing"
+	"returntypes-langserver/processing/pipeline"
+)
+
+const (
+	StatusCommand = "status"
+	HelpCommand   = "help"
+)
+
+type command struct {
+	Name        string
+	Description string
+	// True if the command may be restricted to some of the configured projects
+	SelectsProjects bool
+}
+
+var commands = []command{
+	{Name: processing.CloneStep, Description: "clones the repositories of the configured projects", SelectsProjects: true},
+	{Name: processing.CrawlStep, Description: "preprocesses the java code of each project using the crawler", SelectsProjects: true},
+	{Name: processing.ExtractStep, Description: "extracts the methods and the class hierarchy of all projects"},
+	{Name: processing.ExcelStep, Description: "writes the extracted methods to the configured excel sets"},
+	{Name: processing.DatasetStep, Description: "creates the configured datasets from the extracted methods"},
+	{Name: processing.TrainStep, Description: "trains the predictor on the created datasets"},
+	{Name: processing.EvaluateStep, Description: "evaluates the trained models on the created datasets"},
+	{Name: StatusCommand, Description: "prints the checkpoint and the pending actions of each step"},
+	{Name: CompareCommand, Description: "compares the generated methods of two models with significance tests"},
+	{Name: EvaluateFileCommand, Description: "evaluates a model on the methods of a csv file or a java project directory"},
+	{Name: RescoreCommand, Description: "rates the stored generated methods again using the current evaluation configuration"},
+}
+
+// Executes the command with the given arguments and returns the exit code. If the name is empty, the whole dataset
+// creation process is executed.
+func runCommand(name string, arguments []string) int {
+	if name == HelpCommand {
+		printUsage(os.Stdout)
+		return ExitSuccess
+	}
+	cmd, ok := findCommand(name)
+	if !ok && name != "" {
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", name)
+		printUsage(os.Stderr)
+		return ExitUsage
+	} else if name == CompareCommand {
+		return runCompare(arguments)
+	} else if name == EvaluateFileCommand {
+		return runEvaluateFile(arguments)
+	} else if name == RescoreCommand {
+		return runRescore(arguments)
+	}
+
+	flags := flag.NewFlagSet(strings.TrimSpace("datasetcreator "+name), flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags]\n", flags.Name())
+		flags.PrintDefaults()
+	}
+	var dryRun bool
+	if name != StatusCommand {
+		flags.BoolVar(&dryRun, "dry-run", false, "prints the planned actions without executing them")
+	}
+	var projectNames string
+	if cmd.SelectsProjects {
+		flags.StringVar(&projectNames, "projects", "", "comma separated names of the projects to process. If empty, all configured projects are processed")
+	}
+
+	loadErr := configuration.LoadWithFlagSet(flags)
+	if err := flags.Parse(arguments); err == flag.ErrHelp {
+		return ExitSuccess
+	} else if err != nil {
+		return ExitUsage
+	} else if flags.NArg() > 0 {
+		fmt.Fprintf(flags.Output(), "Unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return ExitUsage
+	}
+	SetupLogger()
+	if loadErr != nil {
+		log.Error(loadErr)
+		return ExitFailure
+	}
+
+	processor, err := processing.NewProcessor()
+	if err != nil {
+		log.Error(err)
+		return ExitFailure
+	}
+	if projectNames != "" {
+		if err := processor.SelectProjects(strings.Split(projectNames, ",")); err != nil {
+			log.Error(err)
+			return ExitUsage
+		}
+	}
+
+	if err := execute(processor, name, dryRun); err != nil {
+		log.Error(err)
+		return ExitFailure
+	} else if len(log.GetProblems()) > 0 {
+		return ExitProblems
+	}
+	return ExitSuccess
+}
+
+func execute(processor *processing.Processor, name string, dryRun bool) errors.Error {
+	if name == "" && !dryRun {
+		return processor.ProcessDatasetCreation()
+	}
+	graph, err := processor.Graph()
+	if err != nil {
+		return err
+	} else if name == StatusCommand {
+		return printStatus(os.Stdout, graph)
+	} else if dryRun {
+		return printPlan(os.Stdout, graph, name)
+	}
+
+	// Steps executed explicitly update their checkpoints, so the next run of the whole process may skip them
+	result := graph.RunStep(name)
+	processor.LogProblems()
+	if result.Status == pipeline.StatusFailed {
+		// The error itself was already logged when the step failed
+		return errors.New("Pipeline", "The step %s has failed", name)
+	}
+	return nil
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage:\n")
+	fmt.Fprintf(w, "  datasetcreator [flags]              executes the whole dataset creation process\n")
+	fmt.Fprintf(w, "  datasetcreator <command> [flags]    executes one step of the dataset creation process\n\n")
+	fmt.Fprintf(w, "Commands:\n")
+	tabWriter := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tabWriter, "  %s\t%s\n", cmd.Name, cmd.Description)
+	}
+	tabWriter.Flush()
+	fmt.Fprintf(w, "\nUse \"datasetcreator <command> -help\" for the flags of a command.\n")
+}
+
+// Prints the actions of the step with the given name or of all steps if the name is empty. For the whole process, steps
+// with an up to date checkpoint are skipped as long as none of their dependencies are executed.
+func printPlan(w io.Writer, graph *pipeline.Graph, name string) errors.Error {
+	executed := make(utils.StringSet)
+	for _, step := range graph.Steps() {
+		if name != "" && step.Name != name {
+			continue
+		} else if name == "" {
+			if step.IsDisabled() {
+				fmt.Fprintf(w, "%s: disabled by configuration\n", step.Name)
+				continue
+			}
+			isExecuted, reason, err := isExecutedInRun(graph, step, executed)
+			if err != nil {
+				return err
+			} else if !isExecuted {
+				fmt.Fprintf(w, "%s: skipped, %s\n", step.Name, reason)
+				continue
+			}
+			executed.Put(step.Name)
+			fmt.Fprintf(w, "%s: %s\n", step.Name, reason)
+		} else {
+			fmt.Fprintf(w, "%s:\n", step.Name)
+		}
+
+		actions, err := step.Plan()
+		if err != nil {
+			return err
+		} else if len(actions) == 0 {
+			fmt.Fprintf(w, "  nothing to do\n")
+		}
+		for _, action := range actions {
+			if action.IsSkipped {
+				fmt.Fprintf(w, "  skip  %s\n", action.Description)
+			} else {
+				fmt.Fprintf(w, "  run   %s\n", action.Description)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns true if the step would be executed in a run of the whole process, in which the given steps are executed before.
+func isExecutedInRun(graph *pipeline.Graph, step pipeline.Step, executed utils.StringSet) (bool, string, errors.Error) {
+	if step.IsForced() {
+		return true, "forced by configuration", nil
+	}
+	for _, dependency := range step.DependsOn {
+		if executed.Has(dependency) {
+			return true, fmt.Sprintf("the step %s is executed", dependency), nil
+		}
+	}
+	state, reason, err := graph.CheckpointState(step)
+	return state != pipeline.CheckpointComplete, reason, err
+}
+
+// Prints the checkpoint state and the number of pending actions of each step followed by the pending actions.
+func printStatus(w io.Writer, graph *pipeline.Graph) errors.Error {
+	tabWriter := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	pending := make([]string, 0)
+	for _, step := range graph.Steps() {
+		if step.IsDisabled() {
+			fmt.Fprintf(tabWriter, "%s\tdisabled by configuration\t\n", step.Name)
+			continue
+		}
+
+		state, reason, err := graph.CheckpointState(step)
+		if err != nil {
+			return err
+		}
+		actions, err := step.Plan()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(tabWriter, "%s\t%s (%s)\t%d pending\n", step.Name, state, reason, pipeline.PendingActions(actions))
+		for _, action := range actions {
+			if !action.IsSkipped {
+				pending = append(pending, fmt.Sprintf("%s: %s", step.Name, action.Description))
+			}
+		}
+	}
+	tabWriter.Flush()
+
+	if len(pending) > 0 {
+		fmt.Fprintf(w, "\nPending actions:\n")
+		for _, action := range pending {
+			fmt.Fprintf(w, "  %s\n", action)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/processing/dataset/methodgeneration"
+)
+
+const CompareCommand = "compare"
+
+// Compares the generated methods files of two models on the same evaluation set using the configured metrics and
+// writes the differences with their confidence intervals and p-values.
+func runCompare(arguments []string) int {
+	flags := flag.NewFlagSet("datasetcreator "+CompareCommand, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags] <generated methods of model A> <generated methods of model B>\n", flags.Name())
+		flags.PrintDefaults()
+	}
+	var options methodgeneration.ComparisonOptions
+	var outputPath string
+	flags.IntVar(&options.Samples, "samples", 10000, "the number of bootstrap samples and of the trials of the approximate randomization test")
+	flags.Float64Var(&options.Confidence, "confidence", 0.95, "the confidence level of the confidence intervals")
+	flags.Int64Var(&options.Seed, "seed", 1, "the seed used for resampling")
+	flags.StringVar(&outputPath, "out", "", "the path of the comparison sheet. If empty, it is written to the main output dir")
+
+	loadErr := configuration.LoadWithFlagSet(flags)
+	if err := flags.Parse(arguments); err == flag.ErrHelp {
+		return ExitSuccess
+	} else if err != nil {
+		return ExitUsage
+	} else if flags.NArg() != 2 {
+		flags.Usage()
+		return ExitUsage
+	} else if options.Confidence <= 0 || options.Confidence >= 1 {
+		fmt.Fprintf(flags.Output(), "The confidence level has to be between 0 and 1\n")
+		return ExitUsage
+	}
+	SetupLogger()
+	if loadErr != nil {
+		log.Error(loadErr)
+		return ExitFailure
+	}
+
+	if outputPath == "" {
+		outputPath = filepath.Join(configuration.MainOutputDir(), methodgeneration.ComparisonOutputFile)
+	}
+	if err := methodgeneration.NewComparison(options).CompareFiles(flags.Arg(0), flags.Arg(1), outputPath); err != nil {
+		log.Error(err)
+		return ExitFailure
+	}
+	log.Info("Wrote comparison to %s\n", outputPath)
+	return ExitSuccess
+}
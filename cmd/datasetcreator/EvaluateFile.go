@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/processing/dataset/methodgeneration"
+)
+
+const EvaluateFileCommand = "evaluate-file"
+
+// Evaluates a model on the methods of a methods csv file or of a java project directory, which do not belong to a
+// dataset, and writes the result files to the output directory.
+func runEvaluateFile(arguments []string) int {
+	flags := flag.NewFlagSet("datasetcreator "+EvaluateFileCommand, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags] <methods csv file or java project directory>\n", flags.Name())
+		flags.PrintDefaults()
+	}
+	var options methodgeneration.FileEvaluationOptions
+	var outputPath string
+	flags.StringVar(&options.Model, "model", "", "the reference of a configured dataset or the name of a model of the predictor (required)")
+	flags.StringVar(&options.Checkpoint, "checkpoint", "", "the checkpoint of the model. If empty, the final model is used")
+	flags.StringVar(&options.Evaluation, "evaluation", "", "the path of an evaluation configuration file. If empty, the configured evaluation sets are used")
+	flags.IntVar(&options.Limit, "limit", 0, "the maximum number of evaluated methods. If 0, all methods are evaluated")
+	flags.StringVar(&outputPath, "out", "", "the output directory of the result files. If empty, they are written to the main output dir")
+
+	loadErr := configuration.LoadWithFlagSet(flags)
+	if err := flags.Parse(arguments); err == flag.ErrHelp {
+		return ExitSuccess
+	} else if err != nil {
+		return ExitUsage
+	} else if flags.NArg() != 1 || options.Model == "" {
+		flags.Usage()
+		return ExitUsage
+	}
+	SetupLogger()
+	if loadErr != nil {
+		log.Error(loadErr)
+		return ExitFailure
+	}
+
+	if outputPath == "" {
+		inputName := strings.TrimSuffix(filepath.Base(flags.Arg(0)), filepath.Ext(flags.Arg(0)))
+		outputPath = filepath.Join(configuration.MainOutputDir(), methodgeneration.FileEvaluationOutputDir, inputName, options.Checkpoint)
+	}
+	if err := methodgeneration.EvaluateFile(flags.Arg(0), outputPath, options); err != nil {
+		log.Error(err)
+		return ExitFailure
+	}
+	log.Info("Wrote evaluation result to %s\n", outputPath)
+	return ExitSuccess
+}
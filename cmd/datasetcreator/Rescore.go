@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/processing"
+)
+
+const RescoreCommand = "rescore"
+
+// Rates the generated methods stored by previous evaluations again using the current evaluation configuration. The
+// results are written as new versions next to the previous results.
+func runRescore(arguments []string) int {
+	flags := flag.NewFlagSet("datasetcreator "+RescoreCommand, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags]\n", flags.Name())
+		flags.PrintDefaults()
+	}
+
+	loadErr := configuration.LoadWithFlagSet(flags)
+	if err := flags.Parse(arguments); err == flag.ErrHelp {
+		return ExitSuccess
+	} else if err != nil {
+		return ExitUsage
+	} else if flags.NArg() > 0 {
+		fmt.Fprintf(flags.Output(), "Unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return ExitUsage
+	}
+	SetupLogger()
+	if loadErr != nil {
+		log.Error(loadErr)
+		return ExitFailure
+	}
+
+	processor, err := processing.NewProcessor()
+	if err != nil {
+		log.Error(err)
+		return ExitFailure
+	} else if err := processor.Rescore(); err != nil {
+		log.Error(err)
+		return ExitFailure
+	}
+	return ExitSuccess
+}
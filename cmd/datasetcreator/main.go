@@ -1,21 +1,55 @@
+// Creates datasets from the configured projects and trains the predictor on them.
+//
+// Usage:
+//   datasetcreator [flags]              executes the whole dataset creation process
+//   datasetcreator <command> [flags]    executes one step of the dataset creation process
+//
+// The commands are clone, crawl, extract, excel, dataset, train, evaluate and status. Configuration values which disable
+// a step (like cloner.skip) only apply to the whole process, as commands are executed explicitly.
+//
+// The compare command is no step of the process. It compares the generated methods of two evaluated models (like two
+// alternatives of a dataset) using paired bootstrap and approximate randomization tests:
+//   datasetcreator compare [flags] <generated methods of model A> <generated methods of model B>
+//
+// The evaluate-file command evaluates a configured dataset or a model of the predictor on methods which are not part
+// of a dataset, like the methods of another code base. The methods are read from a methods csv file or extracted from
+// a java project directory using the crawler:
+//   datasetcreator evaluate-file -model <dataset or model> [flags] <methods csv file or java project directory>
+//
+// The rescore command rates the generated methods stored by previous evaluations again using the current evaluation
+// configuration without running the predictor. The results are written as new versions (like
+// methodgeneration_result_v2.xlsx) next to the previous results.
+//
+// Each completed step writes a checkpoint to the checkpoints directory in the output directory. The whole process
+// skips steps whose checkpoints are up to date and therefore resumes at the first incomplete or invalidated step.
+// Removing a checkpoint file forces the step to be executed again.
+//
+// The exit code is 0 on success, 1 if the process failed, 2 on invalid arguments and 3 if the process finished but
+// problems were reported which may affect the results.
 package main
 
 import (
+	"os"
+	"strings"
+
 	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/common/debug/log"
-	"returntypes-langserver/processing"
 )
 
-func main() {
-	err := configuration.Load(false)
-	SetupLogger()
-	if err != nil {
-		log.FatalError(err)
-	}
+const (
+	ExitSuccess = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+	// The process finished, but problems were reported (like projects which could not be cloned)
+	ExitProblems = 3
+)
 
-	if err := processing.ProcessDatasetCreation(); err != nil {
-		log.FatalError(err)
+func main() {
+	name, arguments := "", os.Args[1:]
+	if len(arguments) > 0 && !strings.HasPrefix(arguments[0], "-") {
+		name, arguments = arguments[0], arguments[1:]
 	}
+	os.Exit(runCommand(name, arguments))
 }
 
 func SetupLogger() {
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+)
+
+const (
+	TableFormat = "table"
+	JsonFormat  = "json"
+	CsvFormat   = "csv"
+)
+
+// A table of string values which may be written in different formats.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+func NewTable(header ...string) *Table {
+	return &Table{
+		Header: header,
+		Rows:   make([][]string, 0),
+	}
+}
+
+func (t *Table) AddRow(values ...string) {
+	t.Rows = append(t.Rows, values)
+}
+
+// Writes the table in the given format to the writer.
+func (t *Table) Write(writer io.Writer, format string) errors.Error {
+	switch format {
+	case TableFormat:
+		return t.writeTable(writer)
+	case JsonFormat:
+		return t.writeJson(writer)
+	case CsvFormat:
+		return csv.NewWriter(writer).WriteAllRecords(append([][]string{t.Header}, t.Rows...))
+	}
+	return errors.New("Inspection Error", "Unsupported format: %s", format)
+}
+
+func (t *Table) writeTable(writer io.Writer) errors.Error {
+	tabWriter := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tabWriter, strings.Join(t.Header, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tabWriter, strings.Join(row, "\t"))
+	}
+	if err := tabWriter.Flush(); err != nil {
+		return errors.Wrap(err, "Inspection Error", "Could not write table")
+	}
+	return nil
+}
+
+// Writes the rows as array of objects using the header values as keys.
+func (t *Table) writeJson(writer io.Writer) errors.Error {
+	objects := make([]map[string]string, len(t.Rows))
+	for i, row := range t.Rows {
+		objects[i] = make(map[string]string, len(t.Header))
+		for j, value := range row {
+			objects[i][t.Header[j]] = value
+		}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(objects); err != nil {
+		return errors.Wrap(err, "Inspection Error", "Could not write json")
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+// Inspects a methods csv file (like the extracted methods) or a split of a method generation dataset. The methods may be
+// filtered using a filter in the same JSON syntax as used in the dataset configuration.
+//
+// Usage:
+//   datasetinspect [-filter <json or @file>] [-mode rows|sample|labels|returntypes|tokens] [-n 20] [-format table|json|csv] <csv file>
+//
+// Examples:
+//   datasetinspect -filter '{"include": {"label": ["getter"]}}' -mode sample -n 10 methods.csv
+//   datasetinspect -mode tokens -format csv methodgeneration_trainingSet.csv
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/processing/statistics"
+)
+
+const (
+	RowsMode        = "rows"
+	SampleMode      = "sample"
+	LabelsMode      = "labels"
+	ReturnTypesMode = "returntypes"
+	TokensMode      = "tokens"
+)
+
+const (
+	// Methods files as created by the extractor
+	MethodsKind = "methods"
+	// Training or evaluation sets of method generation datasets
+	MethodGenerationKind = "methodgeneration"
+)
+
+var filterValue string
+var mode string
+var limit int
+var seed int64
+var format string
+var kind string
+
+func main() {
+	flag.StringVar(&filterValue, "filter", "", "a filter in the JSON syntax of the dataset configuration or @<path> to a JSON file containing the filter")
+	flag.StringVar(&mode, "mode", RowsMode, "what to print: rows, sample (random rows), labels (label histogram), returntypes (return type histogram) or tokens (token length distribution)")
+	flag.IntVar(&limit, "n", 20, "the maximum number of printed rows for the rows and sample mode. If 0, all rows are printed")
+	flag.Int64Var(&seed, "seed", 1, "the seed used for the sample mode")
+	flag.StringVar(&format, "format", TableFormat, "the output format: table, json or csv")
+	flag.StringVar(&kind, "kind", "", "the kind of the csv file: methods or methodgeneration. If empty, the kind is derived from the file name")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <csv file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log.SetLoggingToStdout(true)
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	table, err := inspect(flag.Arg(0))
+	if err != nil {
+		log.FatalError(err)
+	} else if err := table.Write(os.Stdout, format); err != nil {
+		log.FatalError(err)
+	}
+}
+
+func inspect(path string) (*Table, errors.Error) {
+	filter, err := parseFilter(filterValue)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := loadMethods(path)
+	if err != nil {
+		return nil, err
+	}
+	matching := make([]csv.Method, 0, len(methods))
+	for _, method := range methods {
+		if csv.IsMethodIncluded(method, filter) {
+			matching = append(matching, method)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d methods match the filter.\n", len(matching), len(methods))
+
+	switch mode {
+	case RowsMode:
+		if limit > 0 && limit < len(matching) {
+			matching = matching[:limit]
+		}
+		return methodsTable(matching), nil
+	case SampleMode:
+		return methodsTable(sample(matching, limit, seed)), nil
+	case LabelsMode:
+		return histogramTable("Label", matching, func(method csv.Method) []string {
+			if csv.IsEmptyList(method.Labels) {
+				return nil
+			}
+			return method.Labels
+		}), nil
+	case ReturnTypesMode:
+		return histogramTable("Return type", matching, func(method csv.Method) []string {
+			return []string{method.ReturnType}
+		}), nil
+	case TokensMode:
+		return tokensTable(matching)
+	}
+	return nil, errors.New("Inspection Error", "Unsupported mode: %s", mode)
+}
+
+// Parses the filter given as JSON string or as @<path> to a JSON file.
+func parseFilter(filterValue string) (configuration.Filter, errors.Error) {
+	var filter configuration.Filter
+	if filterValue == "" {
+		return filter, nil
+	}
+	contents := []byte(filterValue)
+	if strings.HasPrefix(filterValue, "@") {
+		fileContents, err := ioutil.ReadFile(filterValue[1:])
+		if err != nil {
+			return filter, errors.Wrap(err, "Inspection Error", "Could not read filter file")
+		}
+		contents = fileContents
+	}
+	var value interface{}
+	if err := json.Unmarshal(contents, &value); err != nil {
+		return filter, errors.Wrap(err, "Inspection Error", "Could not parse filter")
+	}
+	err := utils.DecodeMapToStructStrict(value, &filter)
+	return filter, err
+}
+
+// Loads the methods of the csv file. Rows of method generation datasets are mapped to methods, so the same filters apply.
+func loadMethods(path string) ([]csv.Method, errors.Error) {
+	if kind == "" {
+		kind = MethodsKind
+		if strings.HasPrefix(filepath.Base(path), "methodgeneration_") {
+			kind = MethodGenerationKind
+		}
+	}
+
+	switch kind {
+	case MethodsKind:
+		return csv.NewFileReader(path).ReadMethodRecords()
+	case MethodGenerationKind:
+		rows, err := csv.NewFileReader(path).ReadMethodGenerationDatasetRowRecords()
+		if err != nil {
+			return nil, err
+		}
+		methods := make([]csv.Method, len(rows))
+		for i, row := range rows {
+			methods[i] = csv.Method{
+				ClassName:         row.ClassName,
+				MethodName:        row.MethodName,
+				ReturnType:        row.ReturnType,
+				Parameters:        row.Parameters,
+				JavadocSummary:    row.JavadocSummary,
+				JavadocParameters: row.JavadocParameters,
+				JavadocReturn:     row.JavadocReturn,
+			}
+			if row.IsStatic {
+				methods[i].Modifier = []string{"static"}
+			}
+		}
+		return methods, nil
+	}
+	return nil, errors.New("Inspection Error", "Unsupported kind: %s", kind)
+}
+
+// Returns count random methods in their original order.
+func sample(methods []csv.Method, count int, seed int64) []csv.Method {
+	if count <= 0 || count >= len(methods) {
+		return methods
+	}
+	indices := rand.New(rand.NewSource(seed)).Perm(len(methods))[:count]
+	sort.Ints(indices)
+	sampled := make([]csv.Method, count)
+	for i, index := range indices {
+		sampled[i] = methods[index]
+	}
+	return sampled
+}
+
+func methodsTable(methods []csv.Method) *Table {
+	table := NewTable("Class name", "Method name", "Return type", "Parameters", "Labels", "Modifier")
+	for _, method := range methods {
+		table.AddRow(method.ClassName, method.MethodName, method.ReturnType, joinList(method.Parameters), joinList(method.Labels), joinList(method.Modifier))
+	}
+	return table
+}
+
+func joinList(list []string) string {
+	if csv.IsEmptyList(list) {
+		return ""
+	}
+	return strings.Join(list, ", ")
+}
+
+// Counts the values of the methods and returns them sorted by their count in descending order.
+func histogramTable(valueName string, methods []csv.Method, values func(csv.Method) []string) *Table {
+	counts := make(map[string]int)
+	for _, method := range methods {
+		for _, value := range values(method) {
+			counts[value]++
+		}
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] == counts[keys[j]] {
+			return keys[i] < keys[j]
+		}
+		return counts[keys[i]] > counts[keys[j]]
+	})
+
+	table := NewTable(valueName, "Count", "Share")
+	for _, key := range keys {
+		table.AddRow(key, fmt.Sprint(counts[key]), fmt.Sprintf("%.2f%%", float64(counts[key])*100/float64(len(methods))))
+	}
+	return table
+}
+
+// Returns the number of input and output sequences per token count.
+func tokensTable(methods []csv.Method) (*Table, errors.Error) {
+	inputTokens, outputTokens := statistics.TokenCount{}, statistics.TokenCount{}
+	for _, method := range methods {
+		tokens, err := statistics.OutputSequenceTokens(method)
+		if err != nil {
+			return nil, err
+		}
+		outputTokens.Add(tokens)
+		inputTokens.Add(statistics.InputSequenceTokens(method))
+	}
+
+	table := NewTable("Token count", "Input sequences", "Output sequences")
+	maxCount := inputTokens.MaxCount
+	if outputTokens.MaxCount > maxCount {
+		maxCount = outputTokens.MaxCount
+	}
+	for count := 0; count <= maxCount && len(methods) > 0; count++ {
+		table.AddRow(fmt.Sprint(count), fmt.Sprint(rowsWithTokenCount(inputTokens, count)), fmt.Sprint(rowsWithTokenCount(outputTokens, count)))
+	}
+	return table, nil
+}
+
+func rowsWithTokenCount(tokenCount statistics.TokenCount, count int) int {
+	if count < len(tokenCount.RowsPerTokenCount) {
+		return tokenCount.RowsPerTokenCount[count]
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+// Compares the manifests of two datasets and prints their differences (source projects and commits, input files,
+// configuration hashes and row counts).
+//
+// Usage:
+//   manifestdiff <dataset dir or manifest file> <dataset dir or manifest file>
+//
+// Like diff, the exit code is 0 if the manifests are equal, 1 if they differ and 2 if an error occured.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/processing/dataset/manifest"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s <dataset dir or manifest file> <dataset dir or manifest file>\n", os.Args[0])
+	}
+	flag.Parse()
+
+	log.SetLoggingToStdout(true)
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	a, err := manifest.Load(manifestPath(flag.Arg(0)))
+	if err != nil {
+		log.Error(err)
+		os.Exit(2)
+	}
+	b, err := manifest.Load(manifestPath(flag.Arg(1)))
+	if err != nil {
+		log.Error(err)
+		os.Exit(2)
+	}
+
+	differences := manifest.Diff(a, b)
+	for _, difference := range differences {
+		fmt.Println(difference)
+	}
+	if len(differences) > 0 {
+		os.Exit(1)
+	}
+}
+
+// Returns the path of the manifest file if the path points to a dataset directory.
+func manifestPath(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, manifest.FileName)
+	}
+	return path
+}
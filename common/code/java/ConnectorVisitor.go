@@ -22,6 +22,7 @@ func (visitor *ConnectorVisitor) VisitCodeFile(codeFile *CodeFile) {
 func (visitor *ConnectorVisitor) VisitClass(class *Class) {
 	for i := range class.ExtendsImplements {
 		class.ExtendsImplements[i].parentElement = class
+		ConnectTypeArguments(&class.ExtendsImplements[i])
 	}
 	for i := range class.Methods {
 		class.Methods[i].parentElement = class
@@ -45,9 +46,10 @@ func (visitor *ConnectorVisitor) VisitImport(*Import) {
 	// Do nothing. Just needed for implementing the Visitor interface
 }
 
-// Connects the return type and type parameter of a method to the method.
+// Connects the return type, parameters and type parameters of a method to the method.
 func (visitor *ConnectorVisitor) VisitMethod(method *Method) {
 	method.ReturnType.parentElement = method
+	ConnectTypeArguments(&method.ReturnType)
 
 	for i := range method.TypeParameters {
 		method.TypeParameters[i].parentElement = method
@@ -56,6 +58,7 @@ func (visitor *ConnectorVisitor) VisitMethod(method *Method) {
 	for i := range method.Parameters {
 		method.Parameters[i].parentElement = method
 		method.Parameters[i].Type.parentElement = &method.Parameters[i]
+		ConnectTypeArguments(&method.Parameters[i].Type)
 	}
 }
 
@@ -63,5 +66,6 @@ func (visitor *ConnectorVisitor) VisitMethod(method *Method) {
 func (visitor *ConnectorVisitor) VisitTypeParameter(typeParameter *TypeParameter) {
 	for i := range typeParameter.TypeBounds {
 		typeParameter.TypeBounds[i].parentElement = typeParameter
+		ConnectTypeArguments(&typeParameter.TypeBounds[i])
 	}
 }
@@ -0,0 +1,217 @@
+package java
+
+import (
+	"strings"
+	"unicode"
+
+	"returntypes-langserver/common/configuration"
+)
+
+// The kind of a wildcard type argument
+type WildcardKind string
+
+const (
+	NoWildcard        WildcardKind = ""
+	UnboundedWildcard WildcardKind = "?"
+	ExtendsWildcard   WildcardKind = "extends"
+	SuperWildcard     WildcardKind = "super"
+)
+
+const (
+	TypeArgumentsStart     = "<"
+	TypeArgumentsEnd       = ">"
+	TypeArgumentsSeparator = ", "
+)
+
+// Parses a type name which might contain type arguments (like java.util.Map<String, List<? extends Number>>[]) to a type.
+// The type arguments of the returned type are not connected to their parent. Use ConnectTypeArguments after the type
+// is placed at it's final location.
+func ParseTypeName(typeName string) Type {
+	parser := typeNameParser{input: typeName}
+	return parser.parseType()
+}
+
+// Removes the type arguments of a type name (java.util.List<java.lang.String> -> java.util.List).
+func EraseTypeArguments(typeName string) string {
+	if !strings.Contains(typeName, TypeArgumentsStart) {
+		return typeName
+	}
+	return FormatTypeName(typeName, configuration.ErasedTypes, nil)
+}
+
+// Parses the type name and formats it again using the given rendering mode. The name mapper is applied to each type name
+// (like shortening the name to it's simple name). If it is nil, the type names are used as they are.
+func FormatTypeName(typeName string, rendering configuration.TypeRendering, nameMapper func(string) string) string {
+	if nameMapper == nil {
+		nameMapper = func(name string) string { return name }
+	}
+	t := ParseTypeName(typeName)
+	return t.Format(rendering, func(t *Type) string {
+		return nameMapper(t.TypeName)
+	})
+}
+
+// Formats the type name like FormatTypeName and shortens all type names to their simple names
+// (java.util.Map<java.lang.String, java.util.List> -> Map<String, List>).
+func FormatSimpleTypeName(typeName string, rendering configuration.TypeRendering) string {
+	return FormatTypeName(typeName, rendering, func(name string) string {
+		return name[strings.LastIndex(name, ".")+1:]
+	})
+}
+
+// Formats the type including it's type arguments depending on the rendering mode:
+// - erased: no type arguments (java.util.List)
+// - shallow generic: only the type arguments of the type itself without their type arguments (java.util.Map<java.lang.String, java.util.List>)
+// - full generic: all type arguments (java.util.Map<java.lang.String, java.util.List<java.lang.Integer>>)
+//
+// The name provider returns the name which should be used for a type (e.g. the type name or the resolved type name).
+// If it is nil, the type names are used. The array brackets are only added to type arguments, as array types of the
+// type itself are handled separately in the outputs.
+func (javaType *Type) Format(rendering configuration.TypeRendering, nameProvider func(*Type) string) string {
+	if nameProvider == nil {
+		nameProvider = func(t *Type) string { return t.TypeName }
+	}
+	maxDepth := 0
+	switch rendering {
+	case configuration.ShallowGenericTypes:
+		maxDepth = 1
+	case configuration.FullGenericTypes:
+		maxDepth = -1
+	}
+	return javaType.format(maxDepth, nameProvider)
+}
+
+func (javaType *Type) format(maxDepth int, nameProvider func(*Type) string) string {
+	name := nameProvider(javaType)
+	switch javaType.Wildcard {
+	case UnboundedWildcard:
+		return string(UnboundedWildcard)
+	case ExtendsWildcard, SuperWildcard:
+		name = string(UnboundedWildcard) + " " + string(javaType.Wildcard) + " " + name
+	}
+
+	if maxDepth != 0 && len(javaType.TypeArguments) > 0 {
+		arguments := make([]string, len(javaType.TypeArguments))
+		for i := range javaType.TypeArguments {
+			arguments[i] = javaType.TypeArguments[i].format(maxDepth-1, nameProvider)
+			if javaType.TypeArguments[i].IsArrayType {
+				arguments[i] += ArrayTypeExtension
+			}
+		}
+		name += TypeArgumentsStart + strings.Join(arguments, TypeArgumentsSeparator) + TypeArgumentsEnd
+	}
+	return name
+}
+
+// Splits type arguments contained in the type name (if the type name was written like List<String>) to the type
+// arguments of the type and connects the type arguments to the parent element of the type, so they are resolved
+// in the same scope.
+func ConnectTypeArguments(javaType *Type) {
+	javaType.TypeName = strings.TrimSpace(javaType.TypeName)
+	if strings.Contains(javaType.TypeName, TypeArgumentsStart) && len(javaType.TypeArguments) == 0 {
+		parsed := ParseTypeName(javaType.TypeName)
+		javaType.TypeName = parsed.TypeName
+		javaType.TypeArguments = parsed.TypeArguments
+		javaType.IsArrayType = javaType.IsArrayType || parsed.IsArrayType
+	}
+	for i := range javaType.TypeArguments {
+		javaType.TypeArguments[i].parentElement = javaType.parentElement
+		ConnectTypeArguments(&javaType.TypeArguments[i])
+	}
+}
+
+type typeNameParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeNameParser) parseType() Type {
+	p.skipSpaces()
+	if p.consume("?") {
+		p.skipSpaces()
+		for _, kind := range []WildcardKind{ExtendsWildcard, SuperWildcard} {
+			if p.consume(string(kind)) {
+				bound := p.parseType()
+				bound.Wildcard = kind
+				return bound
+			}
+		}
+		return Type{TypeName: string(UnboundedWildcard), Wildcard: UnboundedWildcard}
+	}
+
+	javaType := Type{TypeName: p.parseName()}
+	for p.consume(TypeArgumentsStart) {
+		javaType.TypeArguments = p.parseTypeArguments()
+		if p.consume(".") {
+			// type of an inner class of a generic class (like Outer<String>.Inner)
+			javaType.TypeName += "." + p.parseName()
+			javaType.TypeArguments = nil
+		}
+	}
+	for {
+		p.skipSpaces()
+		if p.consume("[") {
+			p.consume("]")
+		} else if !p.consume("...") {
+			break
+		}
+		javaType.IsArrayType = true
+	}
+	return javaType
+}
+
+func (p *typeNameParser) parseTypeArguments() []Type {
+	arguments := make([]Type, 0, 2)
+	for p.pos < len(p.input) {
+		p.skipSpaces()
+		if p.consume(TypeArgumentsEnd) {
+			break
+		}
+		start := p.pos
+		arguments = append(arguments, p.parseType())
+		p.skipSpaces()
+		if !p.consume(",") && p.pos == start {
+			// skip unexpected characters to prevent endless loops
+			p.pos++
+		}
+	}
+	return arguments
+}
+
+// Parses a (qualified) name. Annotations in front of the name are skipped.
+func (p *typeNameParser) parseName() string {
+	p.skipSpaces()
+	for p.consume("@") {
+		p.readIdentifier()
+		p.skipSpaces()
+	}
+	return p.readIdentifier()
+}
+
+func (p *typeNameParser) readIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r := rune(p.input[p.pos])
+		if r != '.' && r != '$' && r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r < unicode.MaxASCII {
+			break
+		} else if r == '.' && strings.HasPrefix(p.input[p.pos:], "...") {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *typeNameParser) consume(str string) bool {
+	if strings.HasPrefix(p.input[p.pos:], str) {
+		p.pos += len(str)
+		return true
+	}
+	return false
+}
+
+func (p *typeNameParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
@@ -0,0 +1,41 @@
+package java
+
+import (
+	"strings"
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTypeName(t *testing.T) {
+	// when
+	parsed := ParseTypeName("java.util.Map<String, List<? extends Number[]>>[]")
+
+	// then
+	assert.Equal(t, "java.util.Map", parsed.TypeName)
+	assert.True(t, parsed.IsArrayType)
+	assert.Len(t, parsed.TypeArguments, 2)
+	assert.Equal(t, "String", parsed.TypeArguments[0].TypeName)
+	assert.Equal(t, "List", parsed.TypeArguments[1].TypeName)
+	assert.Len(t, parsed.TypeArguments[1].TypeArguments, 1)
+	bound := parsed.TypeArguments[1].TypeArguments[0]
+	assert.Equal(t, "Number", bound.TypeName)
+	assert.Equal(t, ExtendsWildcard, bound.Wildcard)
+	assert.True(t, bound.IsArrayType)
+}
+
+func TestFormatTypeName(t *testing.T) {
+	typeName := "java.util.Map<java.lang.String,java.util.List<?>>"
+	shorten := func(name string) string {
+		return name[strings.LastIndex(name, ".")+1:]
+	}
+
+	assert.Equal(t, "java.util.Map", FormatTypeName(typeName, configuration.ErasedTypes, nil))
+	assert.Equal(t, "Map<String, List>", FormatTypeName(typeName, configuration.ShallowGenericTypes, shorten))
+	assert.Equal(t, "Map<String, List<?>>", FormatTypeName(typeName, configuration.FullGenericTypes, shorten))
+	assert.Equal(t, "java.util.List", EraseTypeArguments("java.util.List<java.lang.String>"))
+	assert.Equal(t, "Map", FormatSimpleTypeName(typeName, configuration.ErasedTypes))
+	assert.Equal(t, "Map<String, List<?>>", FormatSimpleTypeName(typeName, configuration.FullGenericTypes))
+}
@@ -0,0 +1,115 @@
+package java
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Contains the parts of a javadoc comment which are relevant for the datasets.
+type Javadoc struct {
+	// The first sentence of the description
+	Summary string
+	// The descriptions of the @param tags in the order of their occurence
+	Parameters []JavadocParameter
+	// The description of the @return tag
+	Return string
+}
+
+type JavadocParameter struct {
+	Name        string
+	Description string
+}
+
+var htmlTagPattern = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+var inlineTagPattern = regexp.MustCompile(`\{@[a-zA-Z]+\s*([^}]*)\}`)
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// Parses the raw javadoc comment (including or excluding the comment delimiters) and returns the summary, @param and @return tags.
+// Html tags are removed and inline tags like {@code value} are replaced by their text.
+func ParseJavadoc(comment string) Javadoc {
+	javadoc := Javadoc{}
+	description, blockTags := splitJavadocBlocks(comment)
+	javadoc.Summary = getFirstSentence(cleanJavadocText(description))
+	for _, blockTag := range blockTags {
+		tagName, content := splitFirstWord(blockTag)
+		switch tagName {
+		case "@param":
+			name, description := splitFirstWord(content)
+			if name == "" {
+				continue
+			}
+			javadoc.Parameters = append(javadoc.Parameters, JavadocParameter{
+				Name:        name,
+				Description: cleanJavadocText(description),
+			})
+		case "@return":
+			javadoc.Return = cleanJavadocText(content)
+		}
+	}
+	return javadoc
+}
+
+// Returns true if the javadoc contains no information.
+func (j Javadoc) IsEmpty() bool {
+	return j.Summary == "" && len(j.Parameters) == 0 && j.Return == ""
+}
+
+// Returns the javadoc of the method.
+func (method *Method) ParseJavadoc() Javadoc {
+	return ParseJavadoc(method.JavadocComment)
+}
+
+// Splits the comment into the main description and the block tags. Each block tag starts with it's tag name.
+func splitJavadocBlocks(comment string) (string, []string) {
+	comment = strings.TrimSpace(comment)
+	comment = strings.TrimPrefix(comment, "/**")
+	comment = strings.TrimSuffix(comment, "*/")
+
+	description := strings.Builder{}
+	blockTags := make([]string, 0)
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if strings.HasPrefix(line, "@") {
+			blockTags = append(blockTags, line)
+		} else if len(blockTags) > 0 {
+			blockTags[len(blockTags)-1] += " " + line
+		} else {
+			description.WriteString(line)
+			description.WriteString(" ")
+		}
+	}
+	return description.String(), blockTags
+}
+
+// Removes html tags, resolves inline tags and normalizes whitespaces.
+func cleanJavadocText(text string) string {
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	text = inlineTagPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		content := strings.TrimSpace(inlineTagPattern.FindStringSubmatch(tag)[1])
+		if !strings.HasPrefix(tag, "{@link") {
+			return content
+		}
+		// {@link Type#member label} is replaced by the label if present
+		if reference, label := splitFirstWord(content); label != "" {
+			return label
+		} else {
+			return strings.ReplaceAll(reference, "#", ".")
+		}
+	})
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+}
+
+func getFirstSentence(text string) string {
+	if index := strings.Index(text, ". "); index >= 0 {
+		return text[:index+1]
+	}
+	return text
+}
+
+func splitFirstWord(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if index := strings.IndexAny(text, " \t\r\n"); index >= 0 {
+		return text[:index], strings.TrimSpace(text[index+1:])
+	}
+	return text, ""
+}
@@ -0,0 +1,40 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJavadoc(t *testing.T) {
+	// given
+	comment := `/**
+	 * Reads the {@code <b>next</b>} line from the {@link java.io.Reader reader}. Returns null
+	 * at the end of the stream.
+	 *
+	 * @param reader the reader to read from,
+	 *        which is not closed
+	 * @param trim if true, whitespaces are removed
+	 * @return the line or null
+	 * @throws IOException if the reader fails
+	 */`
+
+	// when
+	javadoc := ParseJavadoc(comment)
+
+	// then
+	assert.Equal(t, "Reads the next line from the reader.", javadoc.Summary)
+	assert.Equal(t, []JavadocParameter{
+		{Name: "reader", Description: "the reader to read from, which is not closed"},
+		{Name: "trim", Description: "if true, whitespaces are removed"},
+	}, javadoc.Parameters)
+	assert.Equal(t, "the line or null", javadoc.Return)
+}
+
+func TestParseEmptyJavadoc(t *testing.T) {
+	// when
+	javadoc := ParseJavadoc("")
+
+	// then
+	assert.True(t, javadoc.IsEmpty())
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"returntypes-langserver/common/code/packagetree"
+	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/common/debug/errors"
 	"returntypes-langserver/common/utils/counter"
 )
@@ -102,6 +103,30 @@ func (resolver *Resolver) Resolve() {
 		resolver.resolveElement(current)
 		current = current.Parent()
 	}
+
+	resolver.resolveTypeArguments()
+}
+
+// Resolves the type arguments of the target type starting from the same point as the target type.
+func (resolver *Resolver) resolveTypeArguments() {
+	start := resolver.start
+	if start == nil {
+		start = resolver.targetType.Parent()
+	}
+
+	for i := range resolver.targetType.TypeArguments {
+		argument := &resolver.targetType.TypeArguments[i]
+		if argument.Wildcard == UnboundedWildcard {
+			argument.resolutionTypeName = argument.TypeName
+			argument.TypeResolutionState = Resolved
+			continue
+		}
+
+		argumentResolver := Resolver{tree: resolver.tree, NoUpdatesOnTreeChange: resolver.NoUpdatesOnTreeChange}
+		argumentResolver.SetTarget(argument)
+		argumentResolver.SetStartPoint(start)
+		argumentResolver.Resolve()
+	}
 }
 
 // Resolve the type by the given element.
@@ -362,3 +387,26 @@ func Resolve(javaType *Type, tree *packagetree.Tree) (resolvedTypeName string, i
 	}
 	return resolver.ResolvedTypeName(), resolver.IsResolved()
 }
+
+// Resolves a type as if it was used inside of the given element (like a class) and returns it's canonical name.
+func ResolveFrom(javaType *Type, start JavaElement, tree *packagetree.Tree) (resolvedTypeName string, isResolved bool) {
+	resolver := Resolver{tree: tree}
+	resolver.NoUpdatesOnTreeChange = true
+	resolver.SetTarget(javaType)
+	resolver.SetStartPoint(start)
+	resolver.Resolve()
+	return resolver.ResolvedTypeName(), resolver.IsResolved()
+}
+
+// Resolves a type and it's type arguments to their canonical names and returns the type name including all type arguments
+// (like java.util.List<java.lang.String>). The type is only marked as resolved if the type and all type arguments are resolved.
+func ResolveWithTypeArguments(javaType *Type, tree *packagetree.Tree) (resolvedTypeName string, isResolved bool) {
+	_, isResolved = Resolve(javaType, tree)
+	resolvedTypeName = javaType.Format(configuration.FullGenericTypes, func(t *Type) string {
+		if t.TypeResolutionState != Resolved {
+			isResolved = false
+		}
+		return t.ResolvedTypeName()
+	})
+	return resolvedTypeName, isResolved
+}
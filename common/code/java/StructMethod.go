@@ -5,6 +5,10 @@ import (
 	"strings"
 )
 
+// A method declaration of the crawler output. The crawler output of a method may contain the following optional element,
+// which is not produced by older crawler versions:
+//   <javadoc>/** The javadoc comment of the method */</javadoc>
+// If the element is missing, the javadoc comment stays empty.
 type Method struct {
 	XMLName            xml.Name        `xml:"method"`
 	MethodName         string          `xml:"name,attr"`
@@ -19,6 +23,7 @@ type Method struct {
 	ReturnTypeRange    Range           `xml:"returnTypeRange>range"`
 	Parameters         []Parameter     `xml:"parameters>parameter"`
 	Modifier           []string        `xml:"modifiers>modifier"`
+	JavadocComment     string          `xml:"javadoc"`
 	parentElement      JavaElement     `xml:"-"`
 }
 
@@ -10,9 +10,13 @@ import (
 // the type definition is removed from the package tree, the resolution state of the type
 // will be resetted and needs to be resolved again (if possible).
 type Type struct {
-	TypeName            string      `xml:",chardata"`
-	IsArrayType         bool        `xml:"isArrayType,attr"`
-	parentElement       JavaElement `xml:"-"`
+	TypeName    string `xml:",chardata"`
+	IsArrayType bool   `xml:"isArrayType,attr"`
+	// The type arguments of generic types (like String for List<String>).
+	TypeArguments []Type `xml:"typeArgument"`
+	// Set if this type is a wildcard type argument. For bounded wildcards (like ? extends Number) the type name is the bound.
+	Wildcard            WildcardKind `xml:"wildcard,attr"`
+	parentElement       JavaElement  `xml:"-"`
 	resolutionTypeName  string
 	TypeResolutionState ResolutionState
 }
@@ -38,6 +42,14 @@ func (javaType *Type) Path() string {
 	return strings.Join([]string{javaType.Parent().Path(), javaType.TypeName}, ".")
 }
 
+// Returns the canonical name of the type if it was resolved. Otherwise the type name is returned.
+func (javaType *Type) ResolvedTypeName() string {
+	if javaType.TypeResolutionState != Resolved || javaType.resolutionTypeName == "" {
+		return javaType.TypeName
+	}
+	return javaType.resolutionTypeName
+}
+
 func (javaType *Type) Parent() JavaElement {
 	return javaType.parentElement
 }
@@ -0,0 +1,229 @@
+// The classfile package reads the header informations (class name, super class, interfaces) of compiled
+// java class files, so that classes of libraries which are only available as binaries (like jars)
+// can be loaded without any further tools.
+package classfile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strings"
+	"unicode"
+
+	"returntypes-langserver/common/debug/errors"
+)
+
+const ClassFileErrorTitle = "Class File Error"
+
+const magicNumber = 0xCAFEBABE
+
+// Access flags of a class file
+const (
+	AccPublic     = 0x0001
+	AccFinal      = 0x0010
+	AccInterface  = 0x0200
+	AccAbstract   = 0x0400
+	AccSynthetic  = 0x1000
+	AccAnnotation = 0x2000
+	AccEnum       = 0x4000
+	AccModule     = 0x8000
+)
+
+// Tags of the constant pool entries
+const (
+	constantUtf8               = 1
+	constantInteger            = 3
+	constantFloat              = 4
+	constantLong               = 5
+	constantDouble             = 6
+	constantClass              = 7
+	constantString             = 8
+	constantFieldref           = 9
+	constantMethodref          = 10
+	constantInterfaceMethodref = 11
+	constantNameAndType        = 12
+	constantMethodHandle       = 15
+	constantMethodType         = 16
+	constantDynamic            = 17
+	constantInvokeDynamic      = 18
+	constantModule             = 19
+	constantPackage            = 20
+)
+
+// Contains the informations of a class file's header. The class names are in their internal binary form (like java/util/Map$Entry).
+type ClassHeader struct {
+	AccessFlags uint16
+	Name        string
+	SuperClass  string
+	Interfaces  []string
+}
+
+type constantPoolEntry struct {
+	tag   byte
+	utf8  string
+	index uint16
+}
+
+type classFileReader struct {
+	reader       *bufio.Reader
+	constantPool []constantPoolEntry
+	err          error
+}
+
+// Reads the header of a class file. The reader is only read until the interfaces of the class are parsed,
+// so fields, methods and attributes of the class file are not read.
+func ReadHeader(r io.Reader) (ClassHeader, errors.Error) {
+	reader := classFileReader{reader: bufio.NewReader(r)}
+	header := reader.readHeader()
+	if reader.err != nil {
+		return ClassHeader{}, errors.Wrap(reader.err, ClassFileErrorTitle, "Could not read class file header")
+	}
+	return header, nil
+}
+
+func (r *classFileReader) readHeader() ClassHeader {
+	if magic := r.u4(); r.err == nil && magic != magicNumber {
+		r.err = errors.New(ClassFileErrorTitle, "Invalid magic number: %X", magic)
+	}
+	r.u2() // minor version
+	r.u2() // major version
+	r.readConstantPool()
+
+	header := ClassHeader{}
+	header.AccessFlags = r.u2()
+	header.Name = r.className(r.u2())
+	header.SuperClass = r.className(r.u2())
+	interfacesCount := r.u2()
+	if r.err != nil {
+		return header
+	}
+	header.Interfaces = make([]string, 0, interfacesCount)
+	for i := 0; i < int(interfacesCount) && r.err == nil; i++ {
+		header.Interfaces = append(header.Interfaces, r.className(r.u2()))
+	}
+	return header
+}
+
+func (r *classFileReader) readConstantPool() {
+	count := r.u2()
+	if r.err != nil {
+		return
+	}
+	// The constant pool is indexed from 1 to count-1
+	r.constantPool = make([]constantPoolEntry, count)
+	for i := 1; i < int(count) && r.err == nil; i++ {
+		entry := constantPoolEntry{tag: r.u1()}
+		switch entry.tag {
+		case constantUtf8:
+			entry.utf8 = string(r.bytes(int(r.u2())))
+		case constantClass, constantString, constantMethodType, constantModule, constantPackage:
+			entry.index = r.u2()
+		case constantMethodHandle:
+			r.bytes(3)
+		case constantInteger, constantFloat, constantFieldref, constantMethodref, constantInterfaceMethodref,
+			constantNameAndType, constantDynamic, constantInvokeDynamic:
+			r.bytes(4)
+		case constantLong, constantDouble:
+			r.bytes(8)
+		default:
+			if r.err == nil {
+				r.err = errors.New(ClassFileErrorTitle, "Unknown constant pool tag %d at index %d", entry.tag, i)
+			}
+		}
+		r.constantPool[i] = entry
+		if entry.tag == constantLong || entry.tag == constantDouble {
+			// long and double values take two entries in the constant pool, the second one is unusable
+			i++
+		}
+	}
+}
+
+// Returns the name of the class which is referenced by the constant pool index. For index 0 (which is used
+// for example as super class for java.lang.Object) an empty string is returned.
+func (r *classFileReader) className(index uint16) string {
+	if r.err != nil || index == 0 {
+		return ""
+	}
+	if int(index) >= len(r.constantPool) || r.constantPool[index].tag != constantClass {
+		r.err = errors.New(ClassFileErrorTitle, "Invalid class reference at constant pool index %d", index)
+		return ""
+	}
+	nameIndex := r.constantPool[index].index
+	if int(nameIndex) >= len(r.constantPool) || r.constantPool[nameIndex].tag != constantUtf8 {
+		r.err = errors.New(ClassFileErrorTitle, "Invalid class name reference at constant pool index %d", nameIndex)
+		return ""
+	}
+	return r.constantPool[nameIndex].utf8
+}
+
+func (r *classFileReader) u1() byte {
+	if b := r.bytes(1); len(b) == 1 {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *classFileReader) u2() uint16 {
+	if b := r.bytes(2); len(b) == 2 {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *classFileReader) u4() uint32 {
+	if b := r.bytes(4); len(b) == 4 {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *classFileReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r.reader, buf); err != nil {
+		r.err = err
+		return nil
+	}
+	return buf
+}
+
+// Returns true if the class is public.
+func (h ClassHeader) IsPublic() bool {
+	return h.AccessFlags&AccPublic != 0
+}
+
+// Returns true if the class file describes a module (module-info.class).
+func (h ClassHeader) IsModule() bool {
+	return h.AccessFlags&AccModule != 0
+}
+
+// Returns true if the class is generated by the compiler.
+func (h ClassHeader) IsSynthetic() bool {
+	return h.AccessFlags&AccSynthetic != 0
+}
+
+// Returns true if the class is an anonymous or local class (which can not be referenced from outside). These classes
+// have binary names where a part after a dollar sign starts with a digit, like java/util/Foo$1 or java/util/Foo$1Local.
+func (h ClassHeader) IsAnonymousOrLocal() bool {
+	parts := strings.Split(h.Name, "$")
+	for _, part := range parts[1:] {
+		if part == "" || unicode.IsDigit(rune(part[0])) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the class may be referenced by other code. This is the case for public classes which are neither
+// synthetic, anonymous/local nor package/module descriptions.
+func (h ClassHeader) IsAccessible() bool {
+	return h.IsPublic() && !h.IsModule() && !h.IsSynthetic() && !h.IsAnonymousOrLocal() &&
+		!strings.HasSuffix(h.Name, "package-info") && !strings.HasSuffix(h.Name, "module-info")
+}
+
+// Converts the binary class name (like java/util/Map$Entry) to the canonical name (like java.util.Map.Entry)
+func CanonicalName(binaryName string) string {
+	return strings.NewReplacer("/", ".", "$", ".").Replace(binaryName)
+}
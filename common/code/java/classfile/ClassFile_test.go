@@ -0,0 +1,171 @@
+package classfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadHeader(t *testing.T) {
+	// given
+	classFile := createClassFile(AccPublic, "com/example/Foo$Bar", "com/example/Base", "java/io/Serializable", "java/lang/Comparable")
+
+	// when
+	header, err := ReadHeader(bytes.NewReader(classFile))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "com/example/Foo$Bar", header.Name)
+	assert.Equal(t, "com/example/Base", header.SuperClass)
+	assert.Equal(t, []string{"java/io/Serializable", "java/lang/Comparable"}, header.Interfaces)
+	assert.True(t, header.IsAccessible())
+}
+
+func TestReadHeaderWithInvalidMagicNumber(t *testing.T) {
+	// given
+	classFile := createClassFile(AccPublic, "com/example/Foo", "java/lang/Object")
+	classFile[0] = 0
+
+	// when
+	_, err := ReadHeader(bytes.NewReader(classFile))
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestReadConstantPoolStoresLongAtItsIndex(t *testing.T) {
+	// given
+	classFile := createClassFile(AccPublic, "com/example/Foo", "java/lang/Object")
+	reader := classFileReader{reader: bufio.NewReader(bytes.NewReader(classFile[8:]))}
+
+	// when
+	reader.readConstantPool()
+
+	// then
+	assert.NoError(t, reader.err)
+	assert.Equal(t, byte(constantLong), reader.constantPool[1].tag)
+	assert.Equal(t, byte(0), reader.constantPool[2].tag)
+	assert.Equal(t, byte(constantUtf8), reader.constantPool[3].tag)
+}
+
+func TestToClassRecord(t *testing.T) {
+	// given
+	headers := []ClassHeader{
+		{AccessFlags: AccPublic, Name: "com/example/Foo$Bar", SuperClass: "com/example/Base", Interfaces: []string{"java/util/Map$Entry"}},
+		{AccessFlags: AccPublic, Name: "com/example/Base", SuperClass: "java/lang/Object"},
+		{AccessFlags: AccPublic, Name: "com/example/Foo$1", SuperClass: "java/lang/Object"},
+		{AccessFlags: 0, Name: "com/example/Internal", SuperClass: "java/lang/Object"},
+	}
+
+	// when
+	records := ToClassRecords(headers)
+
+	// then
+	assert.Len(t, records, 2)
+	assert.Equal(t, "com.example.Base", records[0].ClassName)
+	assert.Empty(t, records[0].Extends)
+	assert.Equal(t, "com.example.Foo.Bar", records[1].ClassName)
+	assert.Equal(t, []string{"com.example.Base", "java.util.Map.Entry"}, records[1].Extends)
+}
+
+func TestParseJimage(t *testing.T) {
+	// given
+	classFile := createClassFile(AccPublic, "java/lang/Foo", "java/lang/Object", "java/io/Serializable")
+	strs := "\x00java.base\x00java/lang\x00Foo\x00class\x00"
+	location := []byte{
+		jimageAttributeModule<<3 | 0, 1,
+		jimageAttributeParent<<3 | 0, 11,
+		jimageAttributeBase<<3 | 0, 21,
+		jimageAttributeExtension<<3 | 0, 25,
+		jimageAttributeOffset<<3 | 0, 0,
+		jimageAttributeUncompressed<<3 | 1, byte(len(classFile) >> 8), byte(len(classFile)),
+		jimageAttributeEnd,
+	}
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, jimageHeader{
+		Magic:         jimageMagicNumber,
+		ResourceCount: 1,
+		TableLength:   1,
+		LocationsSize: uint32(len(location)),
+		StringsSize:   uint32(len(strs)),
+	})
+	binary.Write(&buf, binary.LittleEndian, int32(0))
+	binary.Write(&buf, binary.LittleEndian, uint32(0))
+	buf.Write(location)
+	buf.WriteString(strs)
+	buf.Write(classFile)
+
+	// when
+	headers, err := ParseJimage(buf.Bytes())
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, headers, 1)
+	assert.Equal(t, "java/lang/Foo", headers[0].Name)
+	assert.Equal(t, []string{"java/io/Serializable"}, headers[0].Interfaces)
+}
+
+func TestParseJimageSkipsCompressedResources(t *testing.T) {
+	// given
+	strs := "\x00java.base\x00java/lang\x00Foo\x00class\x00"
+	location := []byte{
+		jimageAttributeBase<<3 | 0, 21,
+		jimageAttributeExtension<<3 | 0, 25,
+		jimageAttributeCompressed<<3 | 0, 10,
+		jimageAttributeUncompressed<<3 | 0, 20,
+		jimageAttributeEnd,
+	}
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, jimageHeader{
+		Magic:         jimageMagicNumber,
+		ResourceCount: 1,
+		TableLength:   1,
+		LocationsSize: uint32(len(location)),
+		StringsSize:   uint32(len(strs)),
+	})
+	binary.Write(&buf, binary.LittleEndian, int32(0))
+	binary.Write(&buf, binary.LittleEndian, uint32(0))
+	buf.Write(location)
+	buf.WriteString(strs)
+
+	// when
+	headers, err := ParseJimage(buf.Bytes())
+
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, headers)
+}
+
+// Creates a class file (only containing the parts which are read by the header reader) with a constant pool containing the class names.
+func createClassFile(accessFlags uint16, name, superClass string, interfaces ...string) []byte {
+	buf := bytes.Buffer{}
+	write := func(v interface{}) { binary.Write(&buf, binary.BigEndian, v) }
+	write(uint32(magicNumber))
+	write(uint16(0))
+	write(uint16(52))
+
+	classNames := append([]string{name, superClass}, interfaces...)
+	// each class needs a utf8 entry and a class entry. Add a long constant which takes two entries.
+	write(uint16(len(classNames)*2 + 3))
+	write(uint8(constantLong))
+	write(uint64(42))
+	for i, className := range classNames {
+		write(uint8(constantUtf8))
+		write(uint16(len(className)))
+		buf.WriteString(className)
+		write(uint8(constantClass))
+		write(uint16(i*2 + 3))
+	}
+
+	write(accessFlags)
+	write(uint16(4))
+	write(uint16(6))
+	write(uint16(len(interfaces)))
+	for i := range interfaces {
+		write(uint16(i*2 + 8))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,78 @@
+package classfile
+
+import (
+	"archive/zip"
+	"sort"
+	"strings"
+
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+)
+
+const ClassFileExtension = ".class"
+
+// Reads the headers of all class files contained in the jar file at the given path.
+func ReadJar(path string) ([]ClassHeader, errors.Error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not open jar file at "+path)
+	}
+	defer archive.Close()
+	return ReadArchive(&archive.Reader)
+}
+
+// Reads the headers of all class files contained in the zip archive.
+func ReadArchive(archive *zip.Reader) ([]ClassHeader, errors.Error) {
+	headers := make([]ClassHeader, 0, len(archive.File))
+	for _, file := range archive.File {
+		if !strings.HasSuffix(file.Name, ClassFileExtension) || strings.HasPrefix(file.Name, "META-INF/") {
+			continue
+		}
+		if header, err := readArchiveEntry(file); err != nil {
+			return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not read class file "+file.Name)
+		} else {
+			headers = append(headers, header)
+		}
+	}
+	return headers, nil
+}
+
+func readArchiveEntry(file *zip.File) (ClassHeader, errors.Error) {
+	reader, err := file.Open()
+	if err != nil {
+		return ClassHeader{}, errors.Wrap(err, ClassFileErrorTitle, "Could not open archive entry")
+	}
+	defer reader.Close()
+	return ReadHeader(reader)
+}
+
+// Maps the accessible classes of the headers to class records in the format of the default library files (like javalang.csv).
+// The extends list contains the super class (if it is not java.lang.Object) and the implemented interfaces using their canonical names.
+func ToClassRecords(headers []ClassHeader) []csv.Class {
+	records := make([]csv.Class, 0, len(headers))
+	for _, header := range headers {
+		if !header.IsAccessible() {
+			continue
+		}
+		records = append(records, header.ToClassRecord())
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ClassName < records[j].ClassName
+	})
+	return records
+}
+
+// Maps the header to a class record.
+func (h ClassHeader) ToClassRecord() csv.Class {
+	extends := make([]string, 0, len(h.Interfaces)+1)
+	if h.SuperClass != "" && h.SuperClass != "java/lang/Object" {
+		extends = append(extends, CanonicalName(h.SuperClass))
+	}
+	for _, i := range h.Interfaces {
+		extends = append(extends, CanonicalName(i))
+	}
+	return csv.Class{
+		ClassName: CanonicalName(h.Name),
+		Extends:   extends,
+	}
+}
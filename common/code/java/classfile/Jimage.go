@@ -0,0 +1,146 @@
+package classfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+)
+
+// The magic number of jimage files (like lib/modules of a JDK since java 9)
+const jimageMagicNumber = 0xCAFEDADA
+
+const jimageHeaderSize = 7 * 4
+
+// Attribute kinds of a location entry in a jimage file
+const (
+	jimageAttributeEnd = iota
+	jimageAttributeModule
+	jimageAttributeParent
+	jimageAttributeBase
+	jimageAttributeExtension
+	jimageAttributeOffset
+	jimageAttributeCompressed
+	jimageAttributeUncompressed
+	jimageAttributeCount
+)
+
+type jimageHeader struct {
+	Magic         uint32
+	Version       uint32
+	Flags         uint32
+	ResourceCount uint32
+	TableLength   uint32
+	LocationsSize uint32
+	StringsSize   uint32
+}
+
+type jimageLocation [jimageAttributeCount]uint64
+
+// Reads the headers of all class files contained in a jimage file (like lib/modules of a JDK).
+// Compressed resources are not supported and skipped with a warning. The jmod files of the JDK contain all classes
+// uncompressed and may be used instead.
+func ReadJimage(path string) ([]ClassHeader, errors.Error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not read jimage file at "+path)
+	}
+	return ParseJimage(contents)
+}
+
+// Returns true if the contents start with the magic number of jimage files.
+func IsJimage(contents []byte) bool {
+	return len(contents) >= 4 && (binary.LittleEndian.Uint32(contents) == jimageMagicNumber || binary.BigEndian.Uint32(contents) == jimageMagicNumber)
+}
+
+// Parses the headers of all class files contained in the jimage file contents. Compressed class files are skipped.
+func ParseJimage(contents []byte) ([]ClassHeader, errors.Error) {
+	if !IsJimage(contents) {
+		return nil, errors.New(ClassFileErrorTitle, "Invalid jimage file")
+	}
+	// the byte order of jimage files is the native byte order of the platform the file was created for.
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if binary.BigEndian.Uint32(contents) == jimageMagicNumber {
+		byteOrder = binary.BigEndian
+	}
+
+	var header jimageHeader
+	if err := binary.Read(bytes.NewReader(contents), byteOrder, &header); err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not read jimage header")
+	}
+
+	offsetsStart := uint64(jimageHeaderSize) + uint64(header.TableLength)*4
+	locationsStart := offsetsStart + uint64(header.TableLength)*4
+	stringsStart := locationsStart + uint64(header.LocationsSize)
+	indexSize := stringsStart + uint64(header.StringsSize)
+	if indexSize > uint64(len(contents)) {
+		return nil, errors.New(ClassFileErrorTitle, "Invalid jimage index size")
+	}
+	locations := contents[locationsStart:stringsStart]
+	strs := contents[stringsStart:indexSize]
+
+	headers := make([]ClassHeader, 0, header.TableLength)
+	compressedCount := 0
+	for i := uint64(0); i < uint64(header.TableLength); i++ {
+		offset := byteOrder.Uint32(contents[offsetsStart+i*4:])
+		location := decodeJimageLocation(locations, uint64(offset))
+		if jimageString(strs, location[jimageAttributeExtension]) != "class" ||
+			jimageString(strs, location[jimageAttributeBase]) == "module-info" {
+			continue
+		} else if location[jimageAttributeCompressed] != 0 {
+			compressedCount++
+			continue
+		}
+
+		start := indexSize + location[jimageAttributeOffset]
+		end := start + location[jimageAttributeUncompressed]
+		if end > uint64(len(contents)) {
+			return nil, errors.New(ClassFileErrorTitle, "Invalid resource location in jimage file")
+		}
+		classHeader, err := ReadHeader(bytes.NewReader(contents[start:end]))
+		if err != nil {
+			return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not read class file "+jimageString(strs, location[jimageAttributeBase]))
+		}
+		headers = append(headers, classHeader)
+	}
+	if compressedCount > 0 {
+		log.ReportProblem("Skipped %d compressed class files of the jimage file, as compressed resources are not supported. Use the jmod files of the JDK to read all classes.\n", compressedCount)
+	}
+	return headers, nil
+}
+
+// Decodes the attributes of the location at the given offset. Each attribute starts with a byte containing the
+// kind (upper 5 bits) and the length of the value (lower 3 bits + 1), followed by the value in big endian.
+func decodeJimageLocation(locations []byte, offset uint64) jimageLocation {
+	var location jimageLocation
+	for offset < uint64(len(locations)) {
+		data := locations[offset]
+		offset++
+		kind := data >> 3
+		if kind == jimageAttributeEnd || kind >= jimageAttributeCount {
+			break
+		}
+		length := uint64(data&0x7) + 1
+		value := uint64(0)
+		for j := uint64(0); j < length && offset < uint64(len(locations)); j++ {
+			value = value<<8 | uint64(locations[offset])
+			offset++
+		}
+		location[kind] = value
+	}
+	return location
+}
+
+// Returns the zero terminated string at the given offset of the strings section.
+func jimageString(strs []byte, offset uint64) string {
+	if offset >= uint64(len(strs)) {
+		return ""
+	}
+	end := bytes.IndexByte(strs[offset:], 0)
+	if end < 0 {
+		return string(strs[offset:])
+	}
+	return string(strs[offset : offset+uint64(end)])
+}
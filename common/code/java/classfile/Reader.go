@@ -0,0 +1,104 @@
+package classfile
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+)
+
+// jmod files are zip archives with an additional 4 byte header
+const jmodHeaderSize = 4
+
+// Reads the class headers from the given path, which might be:
+// - a JDK directory (using lib/modules for java 9+ or (jre/)lib/rt.jar for older versions)
+// - a jimage file (like lib/modules)
+// - a jar, zip or jmod file
+// - a single class file
+// - a directory containing class files
+func ReadClasses(path string) ([]ClassHeader, errors.Error) {
+	if utils.DirExists(path) {
+		return readDirectory(path)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jar", ".zip":
+		return ReadJar(path)
+	case ".jmod":
+		return ReadJmod(path)
+	case ClassFileExtension:
+		return readClassFile(path)
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not read file at "+path)
+	} else if IsJimage(contents) {
+		return ParseJimage(contents)
+	}
+	return readZipContents(contents, path)
+}
+
+// Reads the class headers of a jmod file (like jmods/java.base.jmod of a JDK).
+func ReadJmod(path string) ([]ClassHeader, errors.Error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not read jmod file at "+path)
+	} else if len(contents) < jmodHeaderSize {
+		return nil, errors.New(ClassFileErrorTitle, "Invalid jmod file at "+path)
+	}
+	return readZipContents(contents[jmodHeaderSize:], path)
+}
+
+func readZipContents(contents []byte, path string) ([]ClassHeader, errors.Error) {
+	archive, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not open archive at "+path)
+	}
+	return ReadArchive(archive)
+}
+
+func readClassFile(path string) ([]ClassHeader, errors.Error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not open class file at "+path)
+	}
+	defer file.Close()
+	if header, err := ReadHeader(file); err != nil {
+		return nil, err
+	} else {
+		return []ClassHeader{header}, nil
+	}
+}
+
+func readDirectory(dir string) ([]ClassHeader, errors.Error) {
+	// check if the directory is a JDK directory
+	for _, candidate := range []string{filepath.Join("lib", "modules"), filepath.Join("jre", "lib", "rt.jar"), filepath.Join("lib", "rt.jar")} {
+		if path := filepath.Join(dir, candidate); utils.FileExists(path) {
+			return ReadClasses(path)
+		}
+	}
+
+	headers := make([]ClassHeader, 0)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ClassFileExtension) {
+			return err
+		}
+		if classHeaders, err := readClassFile(path); err != nil {
+			return err
+		} else {
+			headers = append(headers, classHeaders...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ClassFileErrorTitle, "Could not read class files in "+dir)
+	}
+	return headers, nil
+}
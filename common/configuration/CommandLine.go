@@ -5,14 +5,14 @@ import (
 	"fmt"
 )
 
-// Creates flags for command line arguments.
-func initCommandLineArguments() {
-	flag.StringVar(&loadedConfig.Cloner.OutputDir, "clonedir", "", "the directory where repositories will be cloned to")
-	flag.StringVar(&loadedConfig.MainOutputDir, "output", "", "the main output dir containing some processing results and the final dataset")
-	flag.BoolVar(&loadedConfig.ForceExtraction, "force", false, "if set, always tries to recollect data from crawler output")
-	flag.StringVar(&loadedConfig.DatasetPrefix, "prefix", "", "additional prefix used for dataset identifiers")
-	flag.StringVar(&loadedConfig.ContinueTraining, "continue", "", "names a dataset for which training should be continued if it does already exist")
-	flag.Func("model", fmt.Sprintf("defines which model type should be used ('%s' or '%s')", MethodGenerator, ReturnTypesValidator), setModelType)
+// Creates flags for command line arguments in the given flag set.
+func initCommandLineArguments(flags *flag.FlagSet) {
+	flags.StringVar(&loadedConfig.Cloner.OutputDir, "clonedir", "", "the directory where repositories will be cloned to")
+	flags.StringVar(&loadedConfig.MainOutputDir, "output", "", "the main output dir containing some processing results and the final dataset")
+	flags.BoolVar(&loadedConfig.ForceExtraction, "force", false, "if set, always tries to recollect data from crawler output")
+	flags.StringVar(&loadedConfig.DatasetPrefix, "prefix", "", "additional prefix used for dataset identifiers")
+	flags.StringVar(&loadedConfig.ContinueTraining, "continue", "", "names a dataset for which training should be continued if it does already exist")
+	flags.Func("model", fmt.Sprintf("defines which model type should be used ('%s' or '%s')", MethodGenerator, ReturnTypesValidator), setModelType)
 }
 
 func setModelType(str string) error {
@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"encoding/json"
+	"flag"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -29,6 +30,10 @@ type configFile struct {
 	DefaultTypeClasses string `json:"defaultTypeClasses"`
 	// Configuration for the crawler
 	Crawler CrawlerConfiguration `json:"crawler"`
+	// Configuration for resolving types of external dependencies
+	Dependencies DependencyConfiguration `json:"dependencies"`
+	// Configuration for removing near-duplicate methods of the corpus before creating the datasets
+	Deduplication DeduplicationConfiguration `json:"deduplication"`
 	// If true, will always recollect the data from the crawled xml files
 	ForceExtraction bool `json:"forceExtraction"`
 	// Defines for which model type the dataset should be generated / which model type should be trained
@@ -91,6 +96,24 @@ type CrawlerConfiguration struct {
 	DefaultJavaVersion int `json:"defaultJavaVersion"`
 }
 
+type DependencyConfiguration struct {
+	// If true, the dependencies declared in the build files (pom.xml, build.gradle) of the projects are loaded from
+	// the local maven repository, so types of these dependencies can be resolved. Disabled by default.
+	Resolve bool `json:"resolve"`
+	// The local maven repository containing the jar files of the dependencies. No files will be downloaded.
+	MavenRepository string `json:"mavenRepository"`
+	// If true, also loads the dependencies declared in the pom files of the dependencies in the local repository.
+	Transitive bool `json:"transitive"`
+}
+
+type DeduplicationConfiguration struct {
+	// If true, near-duplicate methods (e.g. of forked repositories or vendored copies) are removed from the corpus
+	// before the datasets are created. Only one representative of each group of near-duplicates is kept.
+	Active bool `json:"active"`
+	// The minimum estimated similarity (jaccard index) of the fingerprints of two methods to consider them as near-duplicates.
+	Threshold float64 `json:"threshold"`
+}
+
 type PredictorConfiguration struct {
 	// The host of the predictor
 	Host string `json:"host"`
@@ -142,13 +165,22 @@ var loadedConfig *configFile
 func Load(isLangServ bool) errors.Error {
 	createDefaultConfig()
 	SetLangServMode(isLangServ)
-	initCommandLineArguments()
+	initCommandLineArguments(flag.CommandLine)
 	err := loadConfigFromFile()
 	loadCommandLineArguments()
 
 	return err
 }
 
+// Loads the configuration like Load, but adds the command line arguments to the given flag set instead of the default
+// command line. The flag set needs to be parsed by the caller afterwards, so the arguments overwrite the loaded values.
+func LoadWithFlagSet(flags *flag.FlagSet) errors.Error {
+	createDefaultConfig()
+	SetLangServMode(false)
+	initCommandLineArguments(flags)
+	return loadConfigFromFile()
+}
+
 // Loads configurations from a json string. Only the setted values will overwrite the current configuration.
 func LoadConfigFromJsonString(jsonStr string) errors.Error {
 	if loadedConfig == nil {
@@ -178,6 +210,15 @@ func createDefaultConfig() {
 			ExecutablePath:     filepath.Join(GoProjectDir(), "resources", "crawler", "returntypes-crawler.jar"),
 			DefaultJavaVersion: 0,
 		},
+		Dependencies: DependencyConfiguration{
+			Resolve:         false,
+			MavenRepository: defaultMavenRepository(),
+			Transitive:      false,
+		},
+		Deduplication: DeduplicationConfiguration{
+			Active:    false,
+			Threshold: 0.8,
+		},
 		ForceExtraction:    false,
 		SkipIfOutputExists: true,
 		ModelType:          MethodGenerator,
@@ -202,6 +243,14 @@ func createDefaultConfig() {
 	}
 }
 
+// Returns the default location of the local maven repository (~/.m2/repository)
+func defaultMavenRepository() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".m2", "repository")
+	}
+	return ""
+}
+
 func loadConfigFromFile() errors.Error {
 	initializeSchemas()
 	file, err := os.Open(filepath.Join(GoProjectDir(), "config.json"))
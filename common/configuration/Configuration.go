@@ -170,6 +170,41 @@ func CrawlerDefaultJavaVersion() int {
 	return loadedConfig.Crawler.DefaultJavaVersion
 }
 
+func DependenciesResolve() bool {
+	if loadedConfig == nil {
+		return false
+	}
+	return loadedConfig.Dependencies.Resolve
+}
+
+func DependenciesMavenRepository() string {
+	if loadedConfig == nil {
+		return ""
+	}
+	return AbsolutePathFromGoProjectDir(loadedConfig.Dependencies.MavenRepository)
+}
+
+func DependenciesTransitive() bool {
+	if loadedConfig == nil {
+		return false
+	}
+	return loadedConfig.Dependencies.Transitive
+}
+
+func DeduplicationActive() bool {
+	if loadedConfig == nil {
+		return false
+	}
+	return loadedConfig.Deduplication.Active
+}
+
+func DeduplicationThreshold() float64 {
+	if loadedConfig == nil {
+		return 0
+	}
+	return loadedConfig.Deduplication.Threshold
+}
+
 func ForceExtraction() bool {
 	if loadedConfig == nil {
 		return false
@@ -44,8 +44,113 @@ type DatasetCreationOptions struct {
 	FilterDuplicates           bool                    `json:"filterDuplicates,omitempty"`
 	TypeClasses                TypeClassConfigurations `json:"typeClasses,omitempty"`
 	DatasetSize                DatasetProportion       `json:"datasetSize,omitempty"`
+	TypeRendering              TypeRendering           `json:"typeRendering,omitempty"`
+	UseJavadoc                 bool                    `json:"useJavadoc,omitempty"`
+	ExportJsonl                bool                    `json:"exportJsonl,omitempty"`
+	Split                      DatasetSplit            `json:"split"`
+	CrossValidation            CrossValidation         `json:"crossValidation,omitempty"`
+	Sampling                   DatasetSampling         `json:"sampling,omitempty"`
+	Augmentation               DatasetAugmentation     `json:"augmentation,omitempty"`
 }
 
+type CrossValidation struct {
+	// The number of folds. Cross validation is only active for at least two folds.
+	Folds int `json:"folds"`
+}
+
+func (c CrossValidation) IsActive() bool {
+	return c.Folds > 1
+}
+
+// Defines how the methods are sampled by the type class of their return type to balance the dataset.
+type DatasetSampling struct {
+	// Maximum number of methods per type class. The key "*" defines the maximum for all type classes which are not listed.
+	MaxPerTypeClass map[string]int `json:"maxPerTypeClass,omitempty"`
+	// Number of methods each type class should have. Type classes with more methods are undersampled, type classes
+	// with less methods are oversampled in the training set.
+	TargetPerTypeClass int `json:"targetPerTypeClass,omitempty"`
+	// Sampling weight of methods with the given label. Methods with a higher weight are preferred when sampling.
+	// Methods with a weight of 0 are removed.
+	LabelWeights map[string]float64 `json:"labelWeights,omitempty"`
+	// Seed for the random number generator used for sampling.
+	Seed int64 `json:"seed"`
+}
+
+// Returns true if any sampling option is set.
+func (s DatasetSampling) IsActive() bool {
+	return len(s.MaxPerTypeClass) > 0 || s.TargetPerTypeClass > 0 || len(s.LabelWeights) > 0
+}
+
+// Returns the maximum number of methods of the type class or 0 if there is no maximum.
+func (s DatasetSampling) MaxOf(typeClass string) int {
+	if max, ok := s.MaxPerTypeClass[typeClass]; ok {
+		return max
+	}
+	return s.MaxPerTypeClass["*"]
+}
+
+// Defines which augmentations are applied to create additional rows for the training set. Each ratio is the probability
+// of creating an augmented row for a training row.
+type DatasetAugmentation struct {
+	// Ratio of rows for which the verb of the method name is replaced by a synonym (like get/fetch/retrieve)
+	MethodNameSynonyms float64 `json:"methodNameSynonyms,omitempty"`
+	// Ratio of rows for which the parameter order is shuffled. Only applies to parameters of distinct types.
+	ShuffledParameters float64 `json:"shuffledParameters,omitempty"`
+	// Ratio of rows for which the class name and context types are removed
+	DroppedClassContext float64 `json:"droppedClassContext,omitempty"`
+	// Groups of interchangeable verbs. If not set, default groups are used.
+	Synonyms [][]string `json:"synonyms,omitempty"`
+	// Seed for the random number generator used for augmentation.
+	Seed int64 `json:"seed"`
+}
+
+// Returns true if any augmentation is applied.
+func (a DatasetAugmentation) IsActive() bool {
+	return a.MethodNameSynonyms > 0 || a.ShuffledParameters > 0 || a.DroppedClassContext > 0
+}
+
+// Defines how the methods are splitted into training and evaluation set.
+type DatasetSplit struct {
+	Strategy SplitStrategy `json:"strategy"`
+	// Seed for the random number generator used by the random and group based strategies.
+	Seed int64 `json:"seed"`
+}
+
+type SplitStrategy string
+
+const (
+	// The methods are splitted by their position, so the first methods are put into the training set
+	SequentialSplit SplitStrategy = "sequential"
+	// The methods are shuffled before splitting them
+	RandomSplit SplitStrategy = "random"
+	// All methods of a project are put into the same set
+	ProjectSplit SplitStrategy = "project"
+	// All methods of a file are put into the same set
+	FileSplit SplitStrategy = "file"
+	// All methods of a package are put into the same set
+	PackageSplit SplitStrategy = "package"
+)
+
+// Returns the split strategy. If no strategy is set, the sequential strategy is returned.
+func (s DatasetSplit) GetStrategy() SplitStrategy {
+	if s.Strategy == "" {
+		return SequentialSplit
+	}
+	return s.Strategy
+}
+
+// Defines how type arguments of generic types are rendered in the dataset.
+type TypeRendering string
+
+const (
+	// Type arguments are removed (java.util.List)
+	ErasedTypes TypeRendering = "erased"
+	// Only the type arguments of the type itself are rendered but not their type arguments (java.util.Map<java.lang.String, java.util.List>)
+	ShallowGenericTypes TypeRendering = "shallowGeneric"
+	// All type arguments are rendered (java.util.Map<java.lang.String, java.util.List<java.lang.Integer>>)
+	FullGenericTypes TypeRendering = "fullGeneric"
+)
+
 type PreprocessingOptions struct {
 	MaxTrainingRows    int                       `json:"maxTrainingRows,omitempty"`
 	MaxEvaluationRows  int                       `json:"maxEvaluationRows,omitempty"`
@@ -158,6 +263,24 @@ func (c *Dataset) Name() string {
 	return DatasetPrefix() + c.NameRaw
 }
 
+// Returns a dataset for each fold of the cross validation. The name of each fold dataset is suffixed by the fold number.
+// Returns nil if cross validation is not active for the dataset.
+func (c Dataset) Folds() []Dataset {
+	if !c.CreationOptions.CrossValidation.IsActive() {
+		return nil
+	}
+	folds := make([]Dataset, c.CreationOptions.CrossValidation.Folds)
+	for i := range folds {
+		fold := c
+		fold.NameRaw = fmt.Sprintf("%s_fold%d", c.NameRaw, i+1)
+		fold.CreationOptions.CrossValidation = CrossValidation{}
+		fold.Subsets = nil
+		fold.Alternatives = nil
+		folds[i] = fold
+	}
+	return folds
+}
+
 func connectDatasetPaths(datasets []Dataset, parentPath string) {
 	for i := range datasets {
 		datasets[i].parentPath = parentPath
@@ -45,6 +45,15 @@ func (c *EvaluationConfiguration) fromFilePath(filePath string) error {
 	return nil
 }
 
+// Loads the evaluation configuration from a separate file (like the value of the evaluation field in the config file).
+func LoadEvaluationConfiguration(filePath string) (EvaluationConfiguration, errors.Error) {
+	var config EvaluationConfiguration
+	if err := config.fromFilePath(filePath); err != nil {
+		return config, errors.Wrap(err, ConfigurationErrorTitle, "Could not load the evaluation configuration %s", filePath)
+	}
+	return config, nil
+}
+
 type EvaluationSet struct {
 	Name         string                `json:"name"`
 	Subsets      []EvaluationSet       `json:"subsets"`
@@ -141,6 +150,14 @@ const (
 	TokenCounter       = "tokenCounter"
 	CompilabilityMatch = "compilability"
 	ExactMatch         = "exactMatch"
+	TypeResolution     = "typeResolution"
+	ExactMatchAtK      = "exactMatchAtK"
+	MeanReciprocalRank = "meanReciprocalRank"
+	BestOfK            = "bestOfK"
+	Diversity          = "diversity"
+	Components         = "components"
+	// Has no preset as the path of the embeddings is required
+	EmbeddingSimilarity = "embeddingSimilarity"
 )
 
 type MetricConfiguration map[string]interface{}
@@ -178,6 +195,31 @@ func (c MetricConfiguration) DecodeValue(value interface{}) (interface{}, error)
 			return CompilabilityMatchConfiguration{
 				Type: CompilabilityMatch,
 			}, nil
+		case TypeResolution:
+			return TypeResolutionConfiguration{
+				Type: TypeResolution,
+			}, nil
+		case ExactMatchAtK:
+			return ExactMatchAtKConfiguration{
+				Type: ExactMatchAtK,
+			}, nil
+		case MeanReciprocalRank:
+			return MeanReciprocalRankConfiguration{
+				Type: MeanReciprocalRank,
+			}, nil
+		case BestOfK:
+			return BestOfKConfiguration{
+				Type:   BestOfK,
+				Metric: RougeL,
+			}, nil
+		case Diversity:
+			return DiversityConfiguration{
+				Type: Diversity,
+			}, nil
+		case Components:
+			return ComponentsConfiguration{
+				Type: Components,
+			}, nil
 		default:
 			return value, fmt.Errorf("Unsupported metric type preset: %s", metricType)
 		}
@@ -227,6 +269,48 @@ func (c MetricConfiguration) AsCompilabilityMatch() (CompilabilityMatchConfigura
 	return config, err
 }
 
+func (c MetricConfiguration) AsTypeResolution() (TypeResolutionConfiguration, errors.Error) {
+	var config TypeResolutionConfiguration
+	err := c.as(TypeResolution, &config)
+	return config, err
+}
+
+func (c MetricConfiguration) AsExactMatchAtK() (ExactMatchAtKConfiguration, errors.Error) {
+	var config ExactMatchAtKConfiguration
+	err := c.as(ExactMatchAtK, &config)
+	return config, err
+}
+
+func (c MetricConfiguration) AsMeanReciprocalRank() (MeanReciprocalRankConfiguration, errors.Error) {
+	var config MeanReciprocalRankConfiguration
+	err := c.as(MeanReciprocalRank, &config)
+	return config, err
+}
+
+func (c MetricConfiguration) AsBestOfK() (BestOfKConfiguration, errors.Error) {
+	var config BestOfKConfiguration
+	err := c.as(BestOfK, &config)
+	return config, err
+}
+
+func (c MetricConfiguration) AsDiversity() (DiversityConfiguration, errors.Error) {
+	var config DiversityConfiguration
+	err := c.as(Diversity, &config)
+	return config, err
+}
+
+func (c MetricConfiguration) AsComponents() (ComponentsConfiguration, errors.Error) {
+	var config ComponentsConfiguration
+	err := c.as(Components, &config)
+	return config, err
+}
+
+func (c MetricConfiguration) AsEmbeddingSimilarity() (EmbeddingSimilarityConfiguration, errors.Error) {
+	var config EmbeddingSimilarityConfiguration
+	err := c.as(EmbeddingSimilarity, &config)
+	return config, err
+}
+
 func (c MetricConfiguration) as(expectedType string, destination interface{}) errors.Error {
 	if val, ok := c["type"]; !ok || val != expectedType {
 		return errors.New("Type Error", "Cannot interpret metric type '%s' as %s", val, expectedType)
@@ -277,6 +361,51 @@ type CompilabilityMatchConfiguration struct {
 	Type string `json:"type"`
 }
 
+type TypeResolutionConfiguration struct {
+	Type string `json:"type"`
+}
+
+// The metrics over the list of generated suggestions consider the first k suggestions. If k is 0, all suggestions
+// returned by the model (see numReturnSequences) are considered.
+type ExactMatchAtKConfiguration struct {
+	Type string `json:"type"`
+	K    int    `json:"k"`
+}
+
+type MeanReciprocalRankConfiguration struct {
+	Type string `json:"type"`
+	K    int    `json:"k"`
+}
+
+type BestOfKConfiguration struct {
+	Type string `json:"type"`
+	K    int    `json:"k"`
+	// The metric used to score each suggestion (rouge-l, rouge-n or bleu)
+	Metric string `json:"metric"`
+	// The n-gram size if the metric is rouge-n
+	N int `json:"n"`
+}
+
+type DiversityConfiguration struct {
+	Type string `json:"type"`
+	K    int    `json:"k"`
+}
+
+type ComponentsConfiguration struct {
+	Type string `json:"type"`
+	// The number of the most frequent expected return type classes shown in the confusion matrix. Other classes are
+	// grouped together. If 0, the default of 10 classes is used.
+	MaxReturnTypeClasses int `json:"maxReturnTypeClasses"`
+}
+
+type EmbeddingSimilarityConfiguration struct {
+	Type string `json:"type"`
+	// The path of a word vector file in the text format of GloVe or fastText
+	Path string `json:"path"`
+	// The minimum cosine similarity of two names to count them as similar. If 0, the default of 0.7 is used.
+	Threshold float64 `json:"threshold"`
+}
+
 const FScore = "fscore"
 
 type Measure map[string]interface{}
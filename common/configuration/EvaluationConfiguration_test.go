@@ -0,0 +1,40 @@
+package configuration
+
+import (
+	"returntypes-langserver/common/dataformat/jsonschema"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalEvaluationConfigurationWithMetricObjects(t *testing.T) {
+	// given
+	rawStr := `{"subsets":[{"name":"all","metrics":["exactMatchAtK",{"type":"bestOfK","k":3,"metric":"bleu"},{"type":"rouge-n","n":3}]}]}`
+	initializeSchemas()
+
+	// when
+	var config EvaluationConfiguration
+	err := jsonschema.UnmarshalJSONStrict([]byte(rawStr), &config, EvaluationConfigurationFileSchema)
+
+	// then
+	assert.NoError(t, err)
+	metrics := config.Subsets[0].Metrics
+	assert.Len(t, metrics, 3)
+	bestOfK, err := metrics[1].AsBestOfK()
+	assert.NoError(t, err)
+	assert.Equal(t, BestOfKConfiguration{Type: BestOfK, K: 3, Metric: Bleu}, bestOfK)
+	assert.Equal(t, ExactMatchAtK, metrics[0].Type())
+}
+
+func TestUnmarshalEvaluationConfigurationWithUnknownMetric(t *testing.T) {
+	// given
+	rawStr := `{"subsets":[{"name":"all","metrics":[{"type":"bestOfK","metric":"unknown"}]}]}`
+	initializeSchemas()
+
+	// when
+	var config EvaluationConfiguration
+	err := jsonschema.UnmarshalJSONStrict([]byte(rawStr), &config, EvaluationConfigurationFileSchema)
+
+	// then
+	assert.Error(t, err)
+}
@@ -9,6 +9,8 @@ const (
 	ConfigurationSchemaPath               = "configuration/configuration.schema.json"
 	ClonerConfigurationSchemaPath         = "configuration/cloner-configuration.schema.json"
 	CrawlerConfigurationSchemaPath        = "configuration/crawler-configuration.schema.json"
+	DependencyConfigurationSchemaPath     = "configuration/dependency-configuration.schema.json"
+	DeduplicationConfigurationSchemaPath  = "configuration/deduplication-configuration.schema.json"
 	ConnectionsConfigurationSchemaPath    = "configuration/connections-configuration.schema.json"
 	LoggerConfigurationSchemaPath         = "configuration/logger-configuration.schema.json"
 	PredictorConfigurationSchemaPath      = "configuration/predictor-configuration.schema.json"
@@ -38,6 +40,7 @@ const (
 	DatasetCreationOptionsSchemaPath      = "datasets/dataset/creation-options.schema.json"
 	DatasetPreprocessingOptionsSchemaPath = "datasets/dataset/preprocessing-options.schema.json"
 	DatasetSizeSchemaPath                 = "datasets/dataset/dataset-size.schema.json"
+	DatasetSplitSchemaPath                = "datasets/dataset/dataset-split.schema.json"
 	AdamConfigurationSchemaPath           = "datasets/dataset/adam.schema.json"
 	AdafactorConfigurationSchemaPath      = "datasets/dataset/adafactor.schema.json"
 
@@ -54,6 +57,13 @@ const (
 	MeasuresSchemaPath = "metrics/measures.schema.json"
 	FscoreSchemaPath   = "metrics/fscore.schema.json"
 
+	ExactMatchAtKSchemaPath       = "metrics/exact-match-at-k.schema.json"
+	MeanReciprocalRankSchemaPath  = "metrics/mean-reciprocal-rank.schema.json"
+	BestOfKSchemaPath             = "metrics/best-of-k.schema.json"
+	DiversitySchemaPath           = "metrics/diversity.schema.json"
+	ComponentsSchemaPath          = "metrics/components.schema.json"
+	EmbeddingSimilaritySchemaPath = "metrics/embedding-similarity.schema.json"
+
 	// Model list schema
 	ModelListSchemaPath = "datasets/model-list.schema.json"
 )
@@ -93,7 +103,13 @@ func initializeSchemas() {
 			RougeNSchemaPath,
 			RougeSSchemaPath,
 			MeasuresSchemaPath,
-			FscoreSchemaPath).
+			FscoreSchemaPath,
+			ExactMatchAtKSchemaPath,
+			MeanReciprocalRankSchemaPath,
+			BestOfKSchemaPath,
+			DiversitySchemaPath,
+			ComponentsSchemaPath,
+			EmbeddingSimilaritySchemaPath).
 		MustCompile()
 
 	DatasetConfigurationFileSchema = jsonschema.FromMap(getSchemaMap()).
@@ -104,6 +120,7 @@ func initializeSchemas() {
 			DatasetPreprocessingOptionsSchemaPath,
 			DatasetCreationOptionsSchemaPath,
 			DatasetSizeSchemaPath,
+			DatasetSplitSchemaPath,
 			AdafactorConfigurationSchemaPath,
 			AdamConfigurationSchemaPath,
 			TypeClassSchemaPath,
@@ -122,6 +139,8 @@ func initializeSchemas() {
 		WithTopLevel(ConfigurationSchemaPath).
 		WithResources(ClonerConfigurationSchemaPath,
 			CrawlerConfigurationSchemaPath,
+			DependencyConfigurationSchemaPath,
+			DeduplicationConfigurationSchemaPath,
 			ConnectionsConfigurationSchemaPath,
 			LoggerConfigurationSchemaPath,
 			PredictorConfigurationSchemaPath,
@@ -140,6 +159,12 @@ func initializeSchemas() {
 			RougeSSchemaPath,
 			MeasuresSchemaPath,
 			FscoreSchemaPath,
+			ExactMatchAtKSchemaPath,
+			MeanReciprocalRankSchemaPath,
+			BestOfKSchemaPath,
+			DiversitySchemaPath,
+			ComponentsSchemaPath,
+			EmbeddingSimilaritySchemaPath,
 			MethodContextSchemaPath,
 			DatasetConfigurationBaseSchemaPath,
 			DatasetConfigurationSchemaPath,
@@ -147,6 +172,7 @@ func initializeSchemas() {
 			DatasetCreationOptionsSchemaPath,
 			DatasetPreprocessingOptionsSchemaPath,
 			DatasetSizeSchemaPath,
+			DatasetSplitSchemaPath,
 			AdafactorConfigurationSchemaPath,
 			AdamConfigurationSchemaPath,
 			TypeClassSchemaPath,
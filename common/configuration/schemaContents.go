@@ -47,6 +47,16 @@ func initSchemaMap() {
             "type": "object",
             "$ref": "crawler-configuration.schema.json"
         },
+        "dependencies": {
+            "description": "Configurations for resolving types of external dependencies declared in the build files of the projects",
+            "type": "object",
+            "$ref": "dependency-configuration.schema.json"
+        },
+        "deduplication": {
+            "description": "Configurations for removing near-duplicate methods of the corpus before creating the datasets",
+            "type": "object",
+            "$ref": "deduplication-configuration.schema.json"
+        },
         "forceExtraction": {
             "description": "If true, will always recollect the data from the crawled xml files",
             "type": "boolean"
@@ -160,6 +170,46 @@ func initSchemaMap() {
             "maximum": 17
         }
     }
+}`
+	SchemaMap["configuration/dependency-configuration.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "dependency-configuration.schema.json",
+    "title": "Dependency Configuration",
+    "description": "Contains configurations for resolving types of external dependencies",
+    "type": "object",
+    "properties": {
+        "resolve": {
+            "description": "If true, the dependencies declared in the build files (pom.xml, build.gradle) of the projects are loaded from the local maven repository, so types of these dependencies can be resolved (default: false).",
+            "type": "boolean"
+        },
+        "mavenRepository": {
+            "description": "The local maven repository containing the jar files of the dependencies (default: ~/.m2/repository). No files will be downloaded.",
+            "type": "string"
+        },
+        "transitive": {
+            "description": "If true, also loads the dependencies declared in the pom files of the dependencies in the local repository (default: false).",
+            "type": "boolean"
+        }
+    }
+}`
+	SchemaMap["configuration/deduplication-configuration.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "deduplication-configuration.schema.json",
+    "title": "Deduplication Configuration",
+    "description": "Contains configurations for removing near-duplicate methods (e.g. of forked repositories, vendored copies or generated code) of the corpus",
+    "type": "object",
+    "properties": {
+        "active": {
+            "description": "If true, near-duplicate methods are removed from the corpus before the datasets are created. Only one representative of each group of near-duplicates is kept.",
+            "type": "boolean"
+        },
+        "threshold": {
+            "description": "The minimum estimated similarity (jaccard index between 0 and 1) of the fingerprints of two methods to consider them as near-duplicates (default: 0.8). The fingerprint consists of the normalized signature and the shape of the file path.",
+            "type": "number",
+            "minimum": 0,
+            "maximum": 1
+        }
+    }
 }`
 	SchemaMap["configuration/connections-configuration.schema.json"] = `{
     "$schema": "https://json-schema.org/draft/2020-12/schema",
@@ -818,6 +868,106 @@ func initSchemaMap() {
             "description": "The size of the splitted datasets as a proportion. This property can be redefined by each subset.",
             "type": "object",
             "$ref": "dataset-size.schema.json"
+        },
+        "typeRendering": {
+            "description": "Defines how type arguments of generic types are rendered. 'erased' removes all type arguments (List), 'shallowGeneric' renders only the type arguments of the type itself (Map<String, List>) and 'fullGeneric' renders all type arguments (Map<String, List<Integer>>). Default is 'erased'.",
+            "type": "string",
+            "enum": ["erased", "shallowGeneric", "fullGeneric"]
+        },
+        "split": {
+            "description": "Defines how the methods are splitted into training and evaluation set. The strategy and seed are saved with the dataset configuration, so the split can be reproduced.",
+            "type": "object",
+            "$ref": "dataset-split.schema.json"
+        },
+        "crossValidation": {
+            "description": "If set, the methods are additionally splitted into the given number of folds. For each fold, a model is trained on the other folds and evaluated on the fold itself. The scores of all folds are aggregated into one result.",
+            "type": "object",
+            "properties": {
+                "folds": {
+                    "description": "The number of folds. Cross validation is only active for at least two folds.",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            },
+            "required": ["folds"]
+        },
+        "sampling": {
+            "description": "Balances the dataset by the type class of the return types. If no type classes are defined, the unqualified return type itself is used as type class. Caps and undersampling are applied before splitting, oversampling only to the training set, so duplicated methods never appear in the evaluation set. A summary of the resulting distribution is written next to the dataset files.",
+            "type": "object",
+            "properties": {
+                "maxPerTypeClass": {
+                    "description": "Maximum number of methods per type class. The key '*' defines the maximum for all type classes which are not listed.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "minimum": 0
+                    }
+                },
+                "targetPerTypeClass": {
+                    "description": "Number of methods each type class should have (limited by maxPerTypeClass). Type classes with more methods are undersampled, type classes with less methods are oversampled in the training set by duplicating methods.",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "labelWeights": {
+                    "description": "Sampling weight of methods with the given label, e.g. 'getter', 'setter' or 'chainMethod'. The weight of a method is the product of the weights of it's labels (default 1). Methods with a higher weight are preferred when sampling, methods with a weight of 0 are removed.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "minimum": 0
+                    }
+                },
+                "seed": {
+                    "description": "Seed for the random number generator used for sampling.",
+                    "type": "integer"
+                }
+            }
+        },
+        "augmentation": {
+            "description": "Creates additional rows for the training set by augmenting the training rows. Each ratio is the probability of creating an augmented row for a training row. Augmented rows are never placed in the evaluation set.",
+            "type": "object",
+            "properties": {
+                "methodNameSynonyms": {
+                    "description": "Ratio of rows for which the verb of the method name is replaced by a synonym (e.g. getName -> fetchName).",
+                    "type": "number",
+                    "minimum": 0,
+                    "maximum": 1
+                },
+                "shuffledParameters": {
+                    "description": "Ratio of rows for which the parameter order is shuffled. Only applies to methods whose parameters have distinct types. A trailing array parameter (which might be a varargs parameter) is kept in place.",
+                    "type": "number",
+                    "minimum": 0,
+                    "maximum": 1
+                },
+                "droppedClassContext": {
+                    "description": "Ratio of rows for which the class name and the context types are removed.",
+                    "type": "number",
+                    "minimum": 0,
+                    "maximum": 1
+                },
+                "synonyms": {
+                    "description": "Groups of interchangeable verbs used for the method name synonyms. If not set, default groups like get/fetch/retrieve are used.",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "minItems": 2
+                    }
+                },
+                "seed": {
+                    "description": "Seed for the random number generator used for augmentation.",
+                    "type": "integer"
+                }
+            }
+        },
+        "exportJsonl": {
+            "description": "If true, the training and evaluation set are additionally written as JSONL files containing one method per line in the format passed to the predictor (including the sentence formatting). A dataset_info.json describing the split sizes, the filter and the sentence formatting is written next to them, so the dataset can be used by other training stacks. Default is false.",
+            "type": "boolean"
+        },
+        "useJavadoc": {
+            "description": "If true, the javadoc summary, @param and @return descriptions of the methods are written to the dataset and passed to the model for training and evaluation. Requires a crawler version which outputs the javadoc comments of methods, otherwise the dataset creation fails. Default is false.",
+            "type": "boolean"
         }
     }
 }`
@@ -890,6 +1040,24 @@ func initSchemaMap() {
             }
         }
     }]
+}`
+	SchemaMap["datasets/dataset/dataset-split.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "dataset-split.schema.json",
+    "title": "Dataset split",
+    "description": "Properties to define how the methods of a dataset are splitted into training and evaluation set.",
+    "type": "object",
+    "properties": {
+        "strategy": {
+            "description": "The split strategy. 'sequential' splits the methods by their position, 'random' shuffles the methods before splitting. 'project', 'file' and 'package' put all methods of the same project/file/package into the same set, so no data of them leaks into the other set. The groups are distributed so that the configured proportion is kept as closely as possible. 'project' needs methods which were extracted with their project, otherwise they are splitted by package. Default is 'sequential'.",
+            "type": "string",
+            "enum": ["sequential", "random", "project", "file", "package"]
+        },
+        "seed": {
+            "description": "The seed used for shuffling the methods (for 'random') or the groups of methods (for 'project', 'file' and 'package').",
+            "type": "integer"
+        }
+    }
 }`
 	SchemaMap["datasets/dataset/adam.schema.json"] = `{
     "$schema": "https://json-schema.org/draft/2020-12/schema",
@@ -1000,13 +1168,18 @@ func initSchemaMap() {
     "title": "Metrics",
     "description": "Type for all possible metric types.",
     "type": ["string", "object"],
-    "enum": ["rouge-l", "rouge-s", "rouge-2", "bleu", "tokenCounter", "exactMatch", "compilability"],
     "anyOf": [
-        {"type": "string"},
+        {"type": "string", "enum": ["rouge-l", "rouge-s", "rouge-2", "bleu", "tokenCounter", "exactMatch", "compilability", "typeResolution", "exactMatchAtK", "meanReciprocalRank", "bestOfK", "diversity", "components"]},
         {"type": "object", "$ref": "rouge-l.schema.json"},
         {"type": "object", "$ref": "rouge-s.schema.json"},
         {"type": "object", "$ref": "rouge-n.schema.json"},
-        {"type": "object", "$ref": "bleu.schema.json"}
+        {"type": "object", "$ref": "bleu.schema.json"},
+        {"type": "object", "$ref": "exact-match-at-k.schema.json"},
+        {"type": "object", "$ref": "mean-reciprocal-rank.schema.json"},
+        {"type": "object", "$ref": "best-of-k.schema.json"},
+        {"type": "object", "$ref": "diversity.schema.json"},
+        {"type": "object", "$ref": "components.schema.json"},
+        {"type": "object", "$ref": "embedding-similarity.schema.json"}
     ]
 }`
 	SchemaMap["metrics/bleu.schema.json"] = `{
@@ -1127,6 +1300,143 @@ func initSchemaMap() {
         }
     },
     "required": ["type"]
+}`
+	SchemaMap["metrics/exact-match-at-k.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "exact-match-at-k.schema.json",
+    "title": "Exact match at k",
+    "description": "Share of methods for which one of the first k suggestions matches the expected definition exactly",
+    "type": "object",
+    "properties": {
+        "type": {
+            "type": "string",
+            "enum": ["exactMatchAtK"]
+        },
+        "k": {
+            "type": "number",
+            "description": "The number of suggestions which are considered. If 0, all suggestions returned by the model are considered.",
+            "default": 0,
+            "minimum": 0
+        }
+    },
+    "required": ["type"]
+}`
+	SchemaMap["metrics/mean-reciprocal-rank.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "mean-reciprocal-rank.schema.json",
+    "title": "Mean reciprocal rank",
+    "description": "Mean of the reciprocal rank of the first suggestion matching the expected definition exactly",
+    "type": "object",
+    "properties": {
+        "type": {
+            "type": "string",
+            "enum": ["meanReciprocalRank"]
+        },
+        "k": {
+            "type": "number",
+            "description": "The number of suggestions which are considered. If 0, all suggestions returned by the model are considered.",
+            "default": 0,
+            "minimum": 0
+        }
+    },
+    "required": ["type"]
+}`
+	SchemaMap["metrics/best-of-k.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "best-of-k.schema.json",
+    "title": "Best of k",
+    "description": "Average of the best score of the first k suggestions",
+    "type": "object",
+    "properties": {
+        "type": {
+            "type": "string",
+            "enum": ["bestOfK"]
+        },
+        "k": {
+            "type": "number",
+            "description": "The number of suggestions which are considered. If 0, all suggestions returned by the model are considered.",
+            "default": 0,
+            "minimum": 0
+        },
+        "metric": {
+            "type": "string",
+            "enum": ["rouge-l", "rouge-n", "bleu"],
+            "description": "The metric used to score each suggestion.",
+            "default": "rouge-l"
+        },
+        "n": {
+            "type": "number",
+            "description": "Defines, which type of n-grams should be used if the metric is rouge-n.",
+            "default": 2,
+            "minimum": 1
+        }
+    },
+    "required": ["type"]
+}`
+	SchemaMap["metrics/diversity.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "diversity.schema.json",
+    "title": "Diversity",
+    "description": "Diversity of the first k suggestions of each method",
+    "type": "object",
+    "properties": {
+        "type": {
+            "type": "string",
+            "enum": ["diversity"]
+        },
+        "k": {
+            "type": "number",
+            "description": "The number of suggestions which are considered. If 0, all suggestions returned by the model are considered.",
+            "default": 0,
+            "minimum": 0
+        }
+    },
+    "required": ["type"]
+}`
+	SchemaMap["metrics/components.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "components.schema.json",
+    "title": "Components",
+    "description": "Precision, recall and F1 for the return type, the parameter count, the parameter types and the parameter names separately",
+    "type": "object",
+    "properties": {
+        "type": {
+            "type": "string",
+            "enum": ["components"]
+        },
+        "maxReturnTypeClasses": {
+            "type": "number",
+            "description": "The number of the most frequent expected return type classes shown in the confusion matrix. Other classes are grouped together.",
+            "default": 10,
+            "minimum": 0
+        }
+    },
+    "required": ["type"]
+}`
+	SchemaMap["metrics/embedding-similarity.schema.json"] = `{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    "$id": "embedding-similarity.schema.json",
+    "title": "Embedding similarity",
+    "description": "Similarity of the generated parameter names and type names to the expected ones using word vectors of a local embeddings file",
+    "type": "object",
+    "properties": {
+        "type": {
+            "type": "string",
+            "enum": ["embeddingSimilarity"]
+        },
+        "path": {
+            "type": "string",
+            "description": "The path of a word vector file in the text format of GloVe or fastText (a word followed by the values of its vector in each line)."
+        },
+        "threshold": {
+            "type": "number",
+            "description": "The minimum cosine similarity of two names to count them as similar.",
+            "default": 0.7,
+            "minimum": 0,
+            "maximum": 1
+        }
+    },
+    "required": ["type", "path"]
 }`
 	SchemaMap["datasets/model-list.schema.json"] = `{
     "$schema": "https://json-schema.org/draft/2020-12/schema",
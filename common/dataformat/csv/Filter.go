@@ -1,6 +1,9 @@
 package csv
 
-import "returntypes-langserver/common/configuration"
+import (
+	"returntypes-langserver/common/configuration"
+	"strings"
+)
 
 func IsMethodIncluded(method Method, filter configuration.Filter) bool {
 	if filter.Includes != nil {
@@ -45,7 +48,7 @@ func isFilterFulfilled(method Method, f configuration.FilterConfiguration) bool
 		checkPatternsOnTargetList(f.Modifier, method.Modifier) &&
 		checkPatternsOnTargetList(f.Parameter, method.Parameters) &&
 		checkPatternsOnTargetList(f.Label, method.Labels) &&
-		checkPatterns(f.ReturnType, method.ReturnType) &&
+		checkTypePatterns(f.ReturnType, method.ReturnType) &&
 		checkPatterns(f.ClassName, method.ClassName) &&
 		checkPatterns(f.FilePath, method.FilePath) &&
 		isAnyFilterFulfilled(method, f.AnyOf) &&
@@ -64,6 +67,17 @@ func checkPatterns(patterns []configuration.Pattern, target string) bool {
 	return false
 }
 
+// Checks the patterns on the type name. If the type name has type arguments, the patterns are also checked on the
+// type name without type arguments, so patterns for the raw type (like java.util.List) still match generic types.
+func checkTypePatterns(patterns []configuration.Pattern, typeName string) bool {
+	if checkPatterns(patterns, typeName) {
+		return true
+	} else if index := strings.Index(typeName, "<"); index > 0 {
+		return checkPatterns(patterns, typeName[:index])
+	}
+	return false
+}
+
 func checkPatternsOnTargetList(patterns []configuration.Pattern, targets []string) bool {
 	if len(patterns) == 0 {
 		return true
@@ -39,3 +39,29 @@ func TestEmptyFilter(t *testing.T) {
 	assert.True(t, IsMethodIncluded(includedMethod, filter))
 	assert.True(t, IsMethodIncluded(excludedMethod, filter))
 }
+
+func TestReturnTypeFilterOnGenericType(t *testing.T) {
+	filter := configuration.Filter{
+		Includes: configuration.FilterConfigurations{{
+			ReturnType: []configuration.Pattern{{
+				Pattern: "java.util.List",
+				Type:    configuration.Wildcard,
+			}},
+		}},
+	}
+	includedMethod := Method{
+		ReturnType: "java.util.List<java.lang.String>",
+	}
+	excludedMethod := Method{
+		ReturnType: "java.util.Map<java.lang.String, java.util.List<java.lang.String>>",
+	}
+
+	assert.True(t, IsMethodIncluded(includedMethod, filter))
+	assert.False(t, IsMethodIncluded(excludedMethod, filter))
+}
+
+func TestSplitListWithTypeArguments(t *testing.T) {
+	list := SplitList("Map<String,List<Integer>>/map,int/count")
+
+	assert.Equal(t, []string{"Map<String,List<Integer>>/map", "int/count"}, list)
+}
@@ -0,0 +1,61 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalMethodWithoutOptionalColumns(t *testing.T) {
+	// given
+	record := []string{"com.example.User", "java.lang.String", "getName", "", "getter", "public", "name", "src/User.java"}
+
+	// when
+	method, err := UnmarshalMethod(record)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "getName", method.MethodName)
+	assert.Equal(t, "src/User.java", method.FilePath)
+	assert.Empty(t, method.JavadocSummary)
+	assert.Empty(t, method.Project)
+}
+
+func TestUnmarshalMethodWithTooFewColumns(t *testing.T) {
+	// given
+	record := []string{"com.example.User", "java.lang.String", "getName"}
+
+	// when
+	_, err := UnmarshalMethod(record)
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestUnmarshalMethodGenerationDatasetRowWithoutJavadocColumns(t *testing.T) {
+	// given
+	record := []string{"com.example.User", "get name", "String", "false", "", "User"}
+
+	// when
+	row, err := UnmarshalMethodGenerationDatasetRow(record)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "get name", row.MethodName)
+	assert.Nil(t, row.JavadocParameters)
+}
+
+func TestJavadocParametersWithAngleBrackets(t *testing.T) {
+	// given
+	row := MethodGenerationDatasetRow{
+		IsStatic:          true,
+		JavadocParameters: []string{"a the value which must be a < b", "b the upper bound"},
+	}
+
+	// when
+	unmarshalled, err := UnmarshalMethodGenerationDatasetRow(row.ToRecord())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, row.JavadocParameters, unmarshalled.JavadocParameters)
+}
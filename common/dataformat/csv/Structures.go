@@ -3,15 +3,23 @@ package csv
 // Generate Marshal / Unmarshal methods (-> Marshaller.go)
 //go:generate go run ./marshallerGenerator
 
+// A method extracted from the crawler output. The columns after FilePath are optional, so methods files which were
+// extracted by older versions of this tool (with only the first eight columns) can still be read. The javadoc columns
+// are only filled if the crawler provides the javadoc of methods (see java.Method). The file path is relative to the
+// directory of the project the method was extracted from.
 type Method struct {
-	ClassName  string   `excel:"Class name,width=25"`
-	ReturnType string   `excel:"Return type,width=20"`
-	MethodName string   `excel:"Method name,width=30"`
-	Parameters []string `excel:"Parameters,width=95"`
-	Labels     []string `excel:"Labels,width=15"`
-	Modifier   []string `excel:"Modifier,width=12"`
-	ClassField string   `excel:"Class field,width=10"`
-	FilePath   string   `excel:"File path,hide=true"`
+	ClassName         string   `excel:"Class name,width=25"`
+	ReturnType        string   `excel:"Return type,width=20"`
+	MethodName        string   `excel:"Method name,width=30"`
+	Parameters        []string `excel:"Parameters,width=95"`
+	Labels            []string `excel:"Labels,width=15"`
+	Modifier          []string `excel:"Modifier,width=12"`
+	ClassField        string   `excel:"Class field,width=10"`
+	FilePath          string   `excel:"File path,hide=true"`
+	JavadocSummary    string   `excel:"Javadoc summary,width=50" csv:"optional"`
+	JavadocParameters []string `excel:"Javadoc parameters,width=50" csv:"optional,plainList"`
+	JavadocReturn     string   `excel:"Javadoc return,width=30" csv:"optional"`
+	Project           string   `excel:"Project,hide=true" csv:"optional"`
 }
 
 type Class struct {
@@ -36,6 +44,34 @@ type MethodGenerationDatasetRow struct {
 	IsStatic     bool
 	Parameters   []string
 	ContextTypes []string
+	// The javadoc columns are only filled if javadoc is used for the dataset. They are optional, so datasets created
+	// by older versions of this tool can still be read.
+	JavadocSummary    string   `csv:"optional"`
+	JavadocParameters []string `csv:"optional,plainList"`
+	JavadocReturn     string   `csv:"optional"`
+}
+
+// A method of a method generation evaluation set with the definitions generated by a model. The definitions are
+// formatted as they are rated in the evaluation (like "string [tsp] name [rsp] void").
+type GeneratedMethod struct {
+	ClassName          string
+	MethodName         string
+	ContextTypes       []string
+	ExpectedDefinition string
+	// All generated suggestions in the order of their rank
+	GeneratedDefinitions []string
+}
+
+// Number of methods of a type class in a method generation dataset
+type TypeClassDistribution struct {
+	TypeClass string
+	// The number of methods before sampling
+	Methods int
+	// The number of methods after undersampling
+	SampledMethods int
+	// The number of methods in the training set including oversampled methods (but without augmented methods)
+	TrainingSet   int
+	EvaluationSet int
 }
 
 type TypeLabel struct {
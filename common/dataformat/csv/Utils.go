@@ -32,7 +32,33 @@ func MakeList(str []string) string {
 }
 
 // Turns a string into an array using the csv list seperator defined in the configuration.
+// Seperators inside angle brackets (like in type arguments of generic types: Map<K,V>) are not used for splitting.
 func SplitList(str string) []string {
+	seperator := configuration.CsvListSeperator()
+	if !strings.Contains(str, "<") {
+		return strings.Split(str, seperator)
+	}
+
+	list := make([]string, 0, 1)
+	depth, start := 0, 0
+	for i := 0; i < len(str); i++ {
+		switch {
+		case str[i] == '<':
+			depth++
+		case str[i] == '>' && depth > 0:
+			depth--
+		case depth == 0 && strings.HasPrefix(str[i:], seperator):
+			list = append(list, str[start:i])
+			start = i + len(seperator)
+			i += len(seperator) - 1
+		}
+	}
+	return append(list, str[start:])
+}
+
+// Turns a string into an array using the csv list seperator defined in the configuration. In contrast to SplitList,
+// angle brackets are not considered, so this should be used for lists of free text (like "a < b").
+func SplitPlainList(str string) []string {
 	return strings.Split(str, configuration.CsvListSeperator())
 }
 
@@ -22,11 +22,23 @@ func UnmarshalMethod(record []string) (Method, errors.Error) {
 	result.Modifier = SplitList(record[5])
 	result.ClassField = record[6]
 	result.FilePath = record[7]
+	if len(record) > 8 {
+		result.JavadocSummary = record[8]
+	}
+	if len(record) > 9 {
+		result.JavadocParameters = SplitPlainList(record[9])
+	}
+	if len(record) > 10 {
+		result.JavadocReturn = record[10]
+	}
+	if len(record) > 11 {
+		result.Project = record[11]
+	}
 	return result, nil
 }
 
 func (s Method) ToRecord() []string {
-	record := make([]string, 8)
+	record := make([]string, 12)
 	record[0] = s.ClassName
 	record[1] = s.ReturnType
 	record[2] = s.MethodName
@@ -35,6 +47,10 @@ func (s Method) ToRecord() []string {
 	record[5] = MakeList(s.Modifier)
 	record[6] = s.ClassField
 	record[7] = s.FilePath
+	record[8] = s.JavadocSummary
+	record[9] = MakeList(s.JavadocParameters)
+	record[10] = s.JavadocReturn
+	record[11] = s.Project
 	return record
 }
 
@@ -268,17 +284,29 @@ func UnmarshalMethodGenerationDatasetRow(record []string) (MethodGenerationDatas
 	}
 	result.Parameters = SplitList(record[4])
 	result.ContextTypes = SplitList(record[5])
+	if len(record) > 6 {
+		result.JavadocSummary = record[6]
+	}
+	if len(record) > 7 {
+		result.JavadocParameters = SplitPlainList(record[7])
+	}
+	if len(record) > 8 {
+		result.JavadocReturn = record[8]
+	}
 	return result, nil
 }
 
 func (s MethodGenerationDatasetRow) ToRecord() []string {
-	record := make([]string, 6)
+	record := make([]string, 9)
 	record[0] = s.ClassName
 	record[1] = s.MethodName
 	record[2] = s.ReturnType
 	record[3] = strconv.FormatBool(s.IsStatic)
 	record[4] = MakeList(s.Parameters)
 	record[5] = MakeList(s.ContextTypes)
+	record[6] = s.JavadocSummary
+	record[7] = MakeList(s.JavadocParameters)
+	record[8] = s.JavadocReturn
 	return record
 }
 
@@ -322,6 +350,148 @@ func (w *Writer) WriteMethodGenerationDatasetRowRecords(rows []MethodGenerationD
 	return nil
 }
 
+func UnmarshalGeneratedMethod(record []string) (GeneratedMethod, errors.Error) {
+	result := GeneratedMethod{}
+	if len(record) < 5 {
+		return result, errors.New(CsvErrorTitle, "Could not unmarshal to GeneratedMethod: Expected 5 fields but got record with %d fields.", len(record))
+	}
+	result.ClassName = record[0]
+	result.MethodName = record[1]
+	result.ContextTypes = SplitList(record[2])
+	result.ExpectedDefinition = record[3]
+	result.GeneratedDefinitions = SplitList(record[4])
+	return result, nil
+}
+
+func (s GeneratedMethod) ToRecord() []string {
+	record := make([]string, 5)
+	record[0] = s.ClassName
+	record[1] = s.MethodName
+	record[2] = MakeList(s.ContextTypes)
+	record[3] = s.ExpectedDefinition
+	record[4] = MakeList(s.GeneratedDefinitions)
+	return record
+}
+
+func MarshalGeneratedMethod(records []GeneratedMethod) [][]string {
+	result := make([][]string, len(records))
+	for i := range records {
+		result[i] = records[i].ToRecord()
+	}
+	return result
+}
+
+func (r *Reader) ReadGeneratedMethodRecords() ([]GeneratedMethod, errors.Error) {
+	defer r.Close()
+	rows := make([]GeneratedMethod, 0, 8)
+	for {
+		if record, err := r.ReadRecord(); err != nil {
+			if err.Is(errors.EOF) {
+				return rows, nil
+			}
+			return nil, err
+		} else if unmarshalled, err := UnmarshalGeneratedMethod(record); err != nil {
+			return nil, err
+		} else {
+			rows = append(rows, unmarshalled)
+		}
+	}
+}
+
+func (w *Writer) WriteGeneratedMethodRecords(rows []GeneratedMethod) errors.Error {
+	defer w.Close()
+	for _, row := range rows {
+		if err := w.WriteRecord(row.ToRecord()); err != nil {
+			w.err = err
+			return err
+		}
+	}
+
+	if w.destination.Flush(); w.destination.Error() != nil {
+		return errors.Wrap(w.destination.Error(), CsvErrorTitle, "Could not write to csv output file")
+	}
+	return nil
+}
+
+func UnmarshalTypeClassDistribution(record []string) (TypeClassDistribution, errors.Error) {
+	result := TypeClassDistribution{}
+	if len(record) < 5 {
+		return result, errors.New(CsvErrorTitle, "Could not unmarshal to TypeClassDistribution: Expected 5 fields but got record with %d fields.", len(record))
+	}
+	result.TypeClass = record[0]
+	if val, err := strconv.Atoi(record[1]); err != nil {
+		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to int: Expected integer value but got '%s'", record[1])
+	} else {
+		result.Methods = val
+	}
+	if val, err := strconv.Atoi(record[2]); err != nil {
+		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to int: Expected integer value but got '%s'", record[2])
+	} else {
+		result.SampledMethods = val
+	}
+	if val, err := strconv.Atoi(record[3]); err != nil {
+		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to int: Expected integer value but got '%s'", record[3])
+	} else {
+		result.TrainingSet = val
+	}
+	if val, err := strconv.Atoi(record[4]); err != nil {
+		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to int: Expected integer value but got '%s'", record[4])
+	} else {
+		result.EvaluationSet = val
+	}
+	return result, nil
+}
+
+func (s TypeClassDistribution) ToRecord() []string {
+	record := make([]string, 5)
+	record[0] = s.TypeClass
+	record[1] = fmt.Sprintf("%d", s.Methods)
+	record[2] = fmt.Sprintf("%d", s.SampledMethods)
+	record[3] = fmt.Sprintf("%d", s.TrainingSet)
+	record[4] = fmt.Sprintf("%d", s.EvaluationSet)
+	return record
+}
+
+func MarshalTypeClassDistribution(records []TypeClassDistribution) [][]string {
+	result := make([][]string, len(records))
+	for i := range records {
+		result[i] = records[i].ToRecord()
+	}
+	return result
+}
+
+func (r *Reader) ReadTypeClassDistributionRecords() ([]TypeClassDistribution, errors.Error) {
+	defer r.Close()
+	rows := make([]TypeClassDistribution, 0, 8)
+	for {
+		if record, err := r.ReadRecord(); err != nil {
+			if err.Is(errors.EOF) {
+				return rows, nil
+			}
+			return nil, err
+		} else if unmarshalled, err := UnmarshalTypeClassDistribution(record); err != nil {
+			return nil, err
+		} else {
+			rows = append(rows, unmarshalled)
+		}
+	}
+}
+
+func (w *Writer) WriteTypeClassDistributionRecords(rows []TypeClassDistribution) errors.Error {
+	defer w.Close()
+	for _, row := range rows {
+		if err := w.WriteRecord(row.ToRecord()); err != nil {
+			w.err = err
+			return err
+		}
+	}
+
+	if w.destination.Flush(); w.destination.Error() != nil {
+		return errors.Wrap(w.destination.Error(), CsvErrorTitle, "Could not write to csv output file")
+	}
+	return nil
+}
+
 func UnmarshalTypeLabel(record []string) (TypeLabel, errors.Error) {
 	result := TypeLabel{}
 	if len(record) < 2 {
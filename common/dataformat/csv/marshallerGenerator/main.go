@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path"
+	"reflect"
 	"returntypes-langserver/common/code/generator"
 	"strings"
 	"text/template"
@@ -34,11 +35,20 @@ func main() {
 type UnmarshallerAttributes struct {
 	TypeName string
 	Fields   []FieldAttributes
+	// The number of fields which must be contained in a record
+	RequiredFields int
 }
 
 type FieldAttributes struct {
 	TypeName string
 	Name     string
+	Index    int
+	// Optional fields may be missing in records written by older versions. They must follow the required fields.
+	// Fields are marked as optional using the tag `csv:"optional"`.
+	Optional bool
+	// Lists of free text (like documentation) are splitted without considering angle brackets of generic types.
+	// Fields are marked as plain lists using the tag `csv:"plainList"`.
+	PlainList bool
 }
 
 func buildUnmarshallerCode(s generator.Struct, outputFile io.Writer) {
@@ -48,12 +58,21 @@ func buildUnmarshallerCode(s generator.Struct, outputFile io.Writer) {
 	}
 	for i, field := range s.Fields {
 		attr.Fields[i] = FieldAttributes{
-			Name:     field.Name,
-			TypeName: field.Type.Code(),
+			Name:      field.Name,
+			TypeName:  field.Type.Code(),
+			Index:     i,
+			Optional:  hasTagOption(field.Tag, "optional"),
+			PlainList: hasTagOption(field.Tag, "plainList"),
 		}
 		if strings.HasPrefix(attr.Fields[i].TypeName, "[]") && attr.Fields[i].TypeName != "[]string" {
 			log.Fatalf("Unsupported type: %s", attr.Fields[i].TypeName)
 		}
+		if !attr.Fields[i].Optional {
+			if i > attr.RequiredFields {
+				log.Fatalf("Required field %s of %s follows an optional field", field.Name, s.Name)
+			}
+			attr.RequiredFields = i + 1
+		}
 	}
 	funcs := template.FuncMap{
 		"isIntegerType": isIntegerType,
@@ -61,11 +80,23 @@ func buildUnmarshallerCode(s generator.Struct, outputFile io.Writer) {
 	}
 	if tmpl, err := template.New("boilerplate").Funcs(funcs).Parse(UnmarshalTemplate); err != nil {
 		log.Fatal(err)
+	} else if tmpl, err := tmpl.Parse(UnmarshalFieldTemplate); err != nil {
+		log.Fatal(err)
 	} else if err := tmpl.Execute(outputFile, attr); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// Returns true if the comma separated options of the csv tag contain the given option.
+func hasTagOption(tag, option string) bool {
+	for _, tagOption := range strings.Split(reflect.StructTag(tag).Get("csv"), ",") {
+		if tagOption == option {
+			return true
+		}
+	}
+	return false
+}
+
 func isIntegerType(str string) bool {
 	switch str {
 	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
@@ -81,33 +112,17 @@ func typeError(typeName string) (string, error) {
 const UnmarshalTemplate = `
 func Unmarshal{{.TypeName}}(record []string) ({{.TypeName}}, errors.Error) {
 	result := {{.TypeName}}{}
-	if len(record) < {{len .Fields}} {
-		return result, errors.New(CsvErrorTitle, "Could not unmarshal to {{.TypeName}}: Expected {{len .Fields}} fields but got record with %d fields.", len(record))
+	if len(record) < {{.RequiredFields}} {
+		return result, errors.New(CsvErrorTitle, "Could not unmarshal to {{.TypeName}}: Expected {{.RequiredFields}} fields but got record with %d fields.", len(record))
 	}
 
-{{- range $i, $e := .Fields}}
-	{{- if eq .TypeName "[]string"}}
-	result.{{.Name}} = SplitList(record[{{$i}}])
-	{{- else if isIntegerType .TypeName}}
-	if val, err := strconv.Atoi(record[{{$i}}]); err != nil {
-		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to {{.TypeName}}: Expected integer value but got '%s'", record[{{$i}}])
-	} else {
-		{{- if eq .TypeName "int"}}
-		result.{{.Name}} = val
-		{{- else}}
-		result.{{.Name}} = {{.TypeName}}(val)
-		{{- end}}
-	}
-	{{- else if eq .TypeName "string"}}
-	result.{{.Name}} = record[{{$i}}]
-	{{- else if eq .TypeName "bool"}}
-	if val, err := strconv.ParseBool(record[{{$i}}]); err != nil {
-		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to {{.TypeName}}: Expected boolean value, but got '%s'", record[{{$i}}])
-	} else {
-		result.{{.Name}} = val
+{{- range .Fields}}
+	{{- if .Optional}}
+	if len(record) > {{.Index}} {
+		{{- template "unmarshalField" .}}
 	}
 	{{- else}}
-		{{typeError .TypeName}}
+	{{- template "unmarshalField" .}}
 	{{- end}}
 {{- end}}
 	return result, nil
@@ -172,6 +187,35 @@ func (w *Writer) Write{{.TypeName}}Records(rows []{{.TypeName}}) errors.Error {
 }
 `
 
+const UnmarshalFieldTemplate = `
+{{- define "unmarshalField"}}
+	{{- if and (eq .TypeName "[]string") .PlainList}}
+	result.{{.Name}} = SplitPlainList(record[{{.Index}}])
+	{{- else if eq .TypeName "[]string"}}
+	result.{{.Name}} = SplitList(record[{{.Index}}])
+	{{- else if isIntegerType .TypeName}}
+	if val, err := strconv.Atoi(record[{{.Index}}]); err != nil {
+		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to {{.TypeName}}: Expected integer value but got '%s'", record[{{.Index}}])
+	} else {
+		{{- if eq .TypeName "int"}}
+		result.{{.Name}} = val
+		{{- else}}
+		result.{{.Name}} = {{.TypeName}}(val)
+		{{- end}}
+	}
+	{{- else if eq .TypeName "string"}}
+	result.{{.Name}} = record[{{.Index}}]
+	{{- else if eq .TypeName "bool"}}
+	if val, err := strconv.ParseBool(record[{{.Index}}]); err != nil {
+		return result, errors.Wrap(err, CsvErrorTitle, "Could not unmarshal to {{.TypeName}}: Expected boolean value, but got '%s'", record[{{.Index}}])
+	} else {
+		result.{{.Name}} = val
+	}
+	{{- else}}
+		{{typeError .TypeName}}
+	{{- end}}
+{{- end}}`
+
 const Imports = `
 import (
 	"fmt"
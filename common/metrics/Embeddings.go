@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+)
+
+// Word vectors loaded from a file in the text format of GloVe or fastText. Each line contains a word followed by the
+// values of its vector. The first line may be a header with the number of words and the dimension (fastText).
+type Embeddings struct {
+	vectors   map[string][]float32
+	dimension int
+}
+
+func LoadEmbeddings(path string) (*Embeddings, errors.Error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Embeddings", "Could not open embeddings file %s", path)
+	}
+	defer file.Close()
+	return ReadEmbeddings(file)
+}
+
+// Reads the embeddings. The dimension is taken from the header or the first vector. As some files contain words with
+// spaces (like GloVe 840B), the last values of each line are used as vector and the remaining fields as word. Lines
+// with less values or invalid values are skipped and reported.
+func ReadEmbeddings(r io.Reader) (*Embeddings, errors.Error) {
+	embeddings := &Embeddings{vectors: make(map[string][]float32)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	skippedLines := 0
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		} else if lineNumber == 1 && isEmbeddingsHeader(fields) {
+			embeddings.dimension, _ = strconv.Atoi(fields[1])
+			continue
+		} else if embeddings.dimension == 0 {
+			embeddings.dimension = len(fields) - 1
+		}
+
+		if word, vector, ok := parseEmbeddingsLine(fields, embeddings.dimension); ok {
+			embeddings.vectors[word] = vector
+		} else {
+			skippedLines++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "Embeddings", "Could not read embeddings")
+	}
+	if skippedLines > 0 {
+		log.ReportProblem("Skipped %d lines of the embeddings which do not contain a word and a vector with %d values\n", skippedLines, embeddings.dimension)
+	}
+	return embeddings, nil
+}
+
+// Parses the word and the vector of a line. Returns false if the line does not contain a valid vector of the dimension.
+func parseEmbeddingsLine(fields []string, dimension int) (string, []float32, bool) {
+	if dimension <= 0 || len(fields) <= dimension {
+		return "", nil, false
+	}
+	wordFields, vectorFields := fields[:len(fields)-dimension], fields[len(fields)-dimension:]
+	vector := make([]float32, dimension)
+	for i, field := range vectorFields {
+		value, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return "", nil, false
+		}
+		vector[i] = float32(value)
+	}
+	return strings.ToLower(strings.Join(wordFields, " ")), vector, true
+}
+
+func isEmbeddingsHeader(fields []string) bool {
+	if len(fields) != 2 {
+		return false
+	}
+	_, countErr := strconv.Atoi(fields[0])
+	_, dimensionErr := strconv.Atoi(fields[1])
+	return countErr == nil && dimensionErr == nil
+}
+
+// Returns the average vector of the known words. Returns false if none of the words is known.
+func (e *Embeddings) Vector(words []string) ([]float64, bool) {
+	sum, known := make([]float64, e.dimension), 0
+	for _, word := range words {
+		if vector, ok := e.vectors[strings.ToLower(word)]; ok {
+			for i, value := range vector {
+				sum[i] += float64(value)
+			}
+			known++
+		}
+	}
+	if known == 0 {
+		return nil, false
+	}
+	for i := range sum {
+		sum[i] /= float64(known)
+	}
+	return sum, true
+}
+
+// Returns the cosine similarity of the average vectors of both word lists. Returns false if one of the lists contains
+// no known word.
+func (e *Embeddings) Similarity(a, b []string) (float64, bool) {
+	vectorA, okA := e.Vector(a)
+	vectorB, okB := e.Vector(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return CosineSimilarity(vectorA, vectorB), true
+}
+
+func CosineSimilarity(a, b []float64) float64 {
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for i := 0; i < len(a) && i < len(b); i++ {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEmbeddingsWithHeader(t *testing.T) {
+	// given
+	file := "2 3\nArray 1 0 0\nlist 0 1 0\n"
+
+	// when
+	embeddings, err := ReadEmbeddings(strings.NewReader(file))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, embeddings.dimension)
+	vector, ok := embeddings.Vector([]string{"array", "list", "unknown"})
+	assert.True(t, ok)
+	assert.Equal(t, []float64{0.5, 0.5, 0}, vector)
+	_, ok = embeddings.Similarity([]string{"array"}, []string{"unknown"})
+	assert.False(t, ok)
+}
+
+func TestReadEmbeddingsSkipsMalformedLines(t *testing.T) {
+	// when
+	embeddings, err := ReadEmbeddings(strings.NewReader("array 1 0 0\nlist 0 1\nmap 0 x 1\nset 0 0 1\n"))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, embeddings.dimension)
+	assert.Len(t, embeddings.vectors, 2)
+	assert.Contains(t, embeddings.vectors, "array")
+	assert.Contains(t, embeddings.vectors, "set")
+}
+
+func TestReadEmbeddingsWithSpacesInWords(t *testing.T) {
+	// given
+	file := "array 1 0 0\n. . . 0 1 0\nat name@domain.com 0 0 1\n"
+
+	// when
+	embeddings, err := ReadEmbeddings(strings.NewReader(file))
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, embeddings.vectors, 3)
+	assert.Equal(t, []float32{0, 1, 0}, embeddings.vectors[". . ."])
+	assert.Equal(t, []float32{0, 0, 1}, embeddings.vectors["at name@domain.com"])
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// The result of a paired bootstrap test on the scores of two systems.
+type BootstrapResult struct {
+	// The mean difference of the paired scores (second system - first system)
+	Difference float64
+	// The bounds of the confidence interval of the difference
+	LowerBound float64
+	UpperBound float64
+	// The probability of a difference at least as large as the observed one if both systems were equally good
+	PValue float64
+}
+
+// Resamples the pairs of scores with replacement and computes the confidence interval of the mean difference (b - a)
+// using the percentile method. The p-value is the share of samples whose difference deviates from the observed
+// difference by at least the observed difference (two sided). a[i] and b[i] have to be the scores of the same item.
+func PairedBootstrap(a, b []float64, samples int, confidence float64, random *rand.Rand) BootstrapResult {
+	differences := pairedDifferences(a, b)
+	result := BootstrapResult{Difference: mean(differences)}
+	if len(differences) == 0 || samples <= 0 {
+		result.PValue = 1
+		return result
+	}
+
+	sampledDifferences := make([]float64, samples)
+	extremeCount := 0
+	for i := range sampledDifferences {
+		sum := 0.0
+		for range differences {
+			sum += differences[random.Intn(len(differences))]
+		}
+		sampledDifferences[i] = sum / float64(len(differences))
+		if math.Abs(sampledDifferences[i]-result.Difference) >= math.Abs(result.Difference) {
+			extremeCount++
+		}
+	}
+	sort.Float64s(sampledDifferences)
+	result.LowerBound = percentile(sampledDifferences, (1-confidence)/2)
+	result.UpperBound = percentile(sampledDifferences, (1+confidence)/2)
+	result.PValue = float64(extremeCount) / float64(samples)
+	return result
+}
+
+// Randomly swaps the scores of each pair and returns the share of trials in which the absolute mean difference is at
+// least as large as the observed one (two sided). a[i] and b[i] have to be the scores of the same item.
+func ApproximateRandomization(a, b []float64, trials int, random *rand.Rand) float64 {
+	differences := pairedDifferences(a, b)
+	if len(differences) == 0 {
+		return 1
+	}
+	observed := math.Abs(mean(differences))
+	extremeCount := 0
+	for i := 0; i < trials; i++ {
+		sum := 0.0
+		for _, difference := range differences {
+			if random.Intn(2) == 0 {
+				sum -= difference
+			} else {
+				sum += difference
+			}
+		}
+		if math.Abs(sum/float64(len(differences))) >= observed {
+			extremeCount++
+		}
+	}
+	// Both counts include the observed assignment, so the p-value is never 0
+	return float64(extremeCount+1) / float64(trials+1)
+}
+
+func pairedDifferences(a, b []float64) []float64 {
+	differences := make([]float64, 0, len(a))
+	for i := 0; i < len(a) && i < len(b); i++ {
+		differences = append(differences, b[i]-a[i])
+	}
+	return differences
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// Returns the value at the given share of the sorted values.
+func percentile(sorted []float64, share float64) float64 {
+	index := int(math.Round(share * float64(len(sorted)-1)))
+	if index < 0 {
+		index = 0
+	} else if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignificanceOfClearDifference(t *testing.T) {
+	// given
+	a, b := make([]float64, 50), make([]float64, 50)
+	for i := range b {
+		a[i] = float64(i%3) * 0.1
+		b[i] = a[i] + 0.5
+	}
+
+	// when
+	bootstrap := PairedBootstrap(a, b, 1000, 0.95, rand.New(rand.NewSource(1)))
+	randomization := ApproximateRandomization(a, b, 1000, rand.New(rand.NewSource(1)))
+
+	// then
+	assert.InDelta(t, 0.5, bootstrap.Difference, 0.0001)
+	assert.InDelta(t, 0.5, bootstrap.LowerBound, 0.0001)
+	assert.InDelta(t, 0.5, bootstrap.UpperBound, 0.0001)
+	assert.Equal(t, 0.0, bootstrap.PValue)
+	assert.Less(t, randomization, 0.01)
+}
+
+func TestSignificanceOfEqualSystems(t *testing.T) {
+	// given
+	a := []float64{1, 0, 1, 0, 1, 0, 1, 0}
+	b := []float64{0, 1, 0, 1, 0, 1, 0, 1}
+
+	// when
+	bootstrap := PairedBootstrap(a, b, 1000, 0.95, rand.New(rand.NewSource(1)))
+	randomization := ApproximateRandomization(a, b, 1000, rand.New(rand.NewSource(1)))
+
+	// then
+	assert.Equal(t, 0.0, bootstrap.Difference)
+	assert.Less(t, bootstrap.LowerBound, 0.0)
+	assert.Greater(t, bootstrap.UpperBound, 0.0)
+	assert.Equal(t, 1.0, bootstrap.PValue)
+	assert.Equal(t, 1.0, randomization)
+}
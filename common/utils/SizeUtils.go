@@ -53,3 +53,11 @@ func BoundIndex(value, length int) int {
 	}
 	return value
 }
+
+// Returns the absolute value of the given value.
+func AbsInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"returntypes-langserver/common/code/java"
 	"returntypes-langserver/common/configuration"
@@ -14,6 +15,7 @@ import (
 	"returntypes-langserver/common/utils"
 	"returntypes-langserver/common/utils/counter"
 	"returntypes-langserver/processing/dataset"
+	"returntypes-langserver/processing/dependencies"
 	"returntypes-langserver/processing/excelOutputter"
 	"returntypes-langserver/processing/extractor"
 	"returntypes-langserver/processing/git"
@@ -27,16 +29,46 @@ type Processor struct {
 }
 
 func ProcessDatasetCreation() errors.Error {
-	processor := Processor{
-		projects: projects.GetProjects(),
-	}
-	if previousProjects, err := LoadPreviousProjectState(); err != nil {
+	processor, err := NewProcessor()
+	if err != nil {
 		return err
-	} else {
-		processor.previousProjects = previousProjects
 	}
-	processor.ProcessDatasetCreation()
-	return SaveProjectState(processor.projects)
+	return processor.ProcessDatasetCreation()
+}
+
+// Creates a processor for the configured projects. The steps of the processor may also be executed independently.
+func NewProcessor() (*Processor, errors.Error) {
+	previousProjects, err := LoadPreviousProjectState()
+	if err != nil {
+		return nil, err
+	}
+	return &Processor{
+		projects:         projects.GetProjects(),
+		previousProjects: previousProjects,
+	}, nil
+}
+
+// Restricts the processed projects to the projects with the given names.
+func (p *Processor) SelectProjects(names []string) errors.Error {
+	selected := make([]projects.Project, 0, len(names))
+	for _, name := range names {
+		project, ok := p.findProject(name)
+		if !ok {
+			return errors.New("Error", "There is no project named '%s' in the configuration", name)
+		}
+		selected = append(selected, project)
+	}
+	p.projects = selected
+	return nil
+}
+
+func (p *Processor) findProject(name string) (projects.Project, bool) {
+	for _, project := range p.projects {
+		if project.Name() == name {
+			return project, true
+		}
+	}
+	return projects.Project{}, false
 }
 
 func LoadPreviousProjectState() ([]projects.Project, errors.Error) {
@@ -74,33 +106,38 @@ func SaveProjectState(currentProjects []projects.Project) errors.Error {
 	return nil
 }
 
-// Executes the dataset creation process
-func (p *Processor) ProcessDatasetCreation() {
-	// First, clone repositories if needed
-	p.clone()
-	// Load the java code of each repository and preprocess it using the crawler
-	p.preprocessJavaCode()
-	// Extract method/classes of all of the repositories and put them into one file for methods and one for classes.
-	p.createBasicData()
-	// Creates excel outputs for excel output configurations
-	p.createExcelOutput()
-	// Create a dataset based on the method/class files above.
-	p.createDataset()
-	// Train the predictor
-	p.trainPredictor()
+// Executes the dataset creation process. Steps which were completed in a previous run and whose inputs did not change
+// are skipped, so a failed run is resumed at the first incomplete step.
+func (p *Processor) ProcessDatasetCreation() errors.Error {
+	graph, err := p.Graph()
+	if err != nil {
+		return err
+	}
+	summary := graph.Run()
+	log.Info("Summary of the dataset creation:\n%s", summary)
 	// Log any problems occured during creation process
-	p.logProblems()
+	p.LogProblems()
+	return summary.Err()
 }
 
-// Clone repositories of repository list if not skipped
-func (p *Processor) clone() {
-	if !configuration.ClonerSkip() {
-		log.Info("Start clone process\n")
-		repositories := p.mapProjectsToRepositoryList(p.projects)
-		if err := git.CloneRepositories(repositories); err != nil {
-			log.ReportProblemWithError(err, "The cloning process was not successful")
+// Clones the repositories of the projects. Repositories which could not be cloned are reported, but do not stop the
+// processing of the other projects, so the clone step always succeeds.
+func (p *Processor) Clone() errors.Error {
+	log.Info("Start clone process\n")
+	repositories := p.mapProjectsToRepositoryList(p.projects)
+	if err := git.CloneRepositories(repositories); err != nil {
+		log.ReportProblemWithError(err, "The cloning process was not successful")
+	}
+	failed := make([]string, 0)
+	for _, repository := range repositories {
+		if cloned, err := git.IsAlreadyCloned(repository.DirName); err != nil || !cloned {
+			failed = append(failed, repository.DirName)
 		}
 	}
+	if len(failed) > 0 {
+		log.ReportProblem("The following repositories could not be cloned and are retried on the next run: %s\n", strings.Join(failed, ", "))
+	}
+	return nil
 }
 
 func (p *Processor) mapProjectsToRepositoryList(projects []projects.Project) []git.RepositoryDefinition {
@@ -114,17 +151,17 @@ func (p *Processor) mapProjectsToRepositoryList(projects []projects.Project) []g
 }
 
 // Preprocess java code using the crawler
-func (p *Processor) preprocessJavaCode() {
+func (p *Processor) PreprocessJavaCode() errors.Error {
 	if err := os.MkdirAll(configuration.CrawlerOutputDir(), 0777); err != nil {
-		log.FatalError(errors.Wrap(err, "Error", "Could not create output directory"))
-	} else {
-		for _, project := range p.projects {
-			hasUpdatedFiles := extractor.PreprocessSourceCodeForProject(project, p.getPreviousProjectStateFor(project))
-			if hasUpdatedFiles {
-				p.isCrawlerFilesUpdated = true
-			}
+		return errors.Wrap(err, "Error", "Could not create output directory")
+	}
+	for _, project := range p.projects {
+		hasUpdatedFiles := extractor.PreprocessSourceCodeForProject(project, p.getPreviousProjectStateFor(project))
+		if hasUpdatedFiles {
+			p.isCrawlerFilesUpdated = true
 		}
 	}
+	return nil
 }
 
 func (p *Processor) getPreviousProjectStateFor(project projects.Project) *projects.Project {
@@ -158,17 +195,23 @@ func (p *Processor) getSymmetricDifference(projectsA, projectsB []projects.Proje
 	return difference
 }
 
-// Creates the basic data for dataset creation (which is a list of all methods and the class hierarchy)
-func (p *Processor) createBasicData() {
+// Creates the basic data for dataset creation (which is a list of all methods and the class hierarchy) if it is not up to
+// date and saves the state of the projects the data is extracted from.
+func (p *Processor) CreateBasicData() errors.Error {
 	if p.isExtractionProcessRequired() {
 		extractor := extractor.Extractor{}
 		extractor.RunOnProjects(p.projects)
 		if extractor.Err() != nil {
-			log.FatalError(extractor.Err())
+			return extractor.Err()
 		}
 		log.Info("Number of failed type resolutions: %d\n", counter.For(java.UnresolvedTypeCounter).GetCount())
 		log.Info("Number of failed type resolutions due to imports of external dependencies: %d\n", counter.For(java.DependencyImportCounter).GetCount())
+		if configuration.DependenciesResolve() {
+			log.Info("Number of classes loaded from dependencies: %d\n", counter.For(dependencies.LoadedClassesCounter).GetCount())
+			log.Info("Number of dependencies not available in the local repository: %d\n", counter.For(dependencies.MissingDependencyCounter).GetCount())
+		}
 	}
+	return SaveProjectState(p.projects)
 }
 
 func (p *Processor) isExtractionProcessRequired() bool {
@@ -181,7 +224,22 @@ func (p *Processor) isDataForDatasetAvailable() bool {
 }
 
 func (p *Processor) isDataForExtractorUpdated() bool {
-	return len(p.getSymmetricDifference(p.projects, p.previousProjects)) > 0 || p.isCrawlerFilesUpdated
+	return len(p.getSymmetricDifference(p.projects, p.previousProjects)) > 0 || p.isCrawlerFilesUpdated || p.isCrawlerOutputNewer()
+}
+
+// Returns true if any crawler output file was written after the methods were extracted, which is the case if the crawler
+// was executed on it's own.
+func (p *Processor) isCrawlerOutputNewer() bool {
+	methods, err := os.Stat(configuration.MethodsWithReturnTypesOutputPath())
+	if err != nil {
+		return false
+	}
+	for _, path := range extractor.GetPreprocessedFilePathForProjects(p.projects) {
+		if crawlerOutput, err := os.Stat(path); err == nil && crawlerOutput.ModTime().After(methods.ModTime()) {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *Processor) isMethodsWithReturnTypesAvailable() bool {
@@ -202,42 +260,43 @@ func (p *Processor) isClassHierarchyAvailable() bool {
 	return true
 }
 
-func (p *Processor) createExcelOutput() {
-	if err := excelOutputter.CreateOutput(p.projects); err != nil {
-		log.FatalError(err)
-	}
+func (p *Processor) CreateExcelOutput() errors.Error {
+	return excelOutputter.CreateOutput(p.projects)
 }
 
 // Creates a dataset
-func (p *Processor) createDataset() {
-	if err := dataset.CreateTrainingAndEvaluationSet(configuration.MethodGenerator, configuration.MethodsWithReturnTypesOutputPath(), configuration.ClassHierarchyOutputPath()); err != nil {
-		log.FatalError(err)
-	}
+func (p *Processor) CreateDataset() errors.Error {
+	return dataset.CreateTrainingAndEvaluationSet(configuration.MethodGenerator, configuration.MethodsWithReturnTypesOutputPath(), configuration.ClassHierarchyOutputPath())
 }
 
-// Trains the predictor with the created dataset if not skipped in configuration
-func (p *Processor) trainPredictor() {
-	if configuration.PredictorSkipTraining() {
-		return
-	}
+// Executes the training process
+func (p *Processor) Train() errors.Error {
 	log.Info("Start training process\n")
-	if err := p.train(); err != nil {
-		log.ReportProblemWithError(errors.Wrap(err, "Training", "Could not train the predictor"), "Could not train the predictor\n")
-	} else {
-		log.Info("Evaluate...\n")
-		if err := dataset.Evaluate(configuration.MethodGenerator); err != nil {
-			log.ReportProblemWithError(errors.Wrap(err, "Evaluation", "Could not evaluate datasets"), "Could not evaluate datasets\n")
-		}
+	/*if err := trainReturnTypes(); err != nil {
+		return err
+	}*/
+	if err := p.trainMethods(); err != nil {
+		return errors.Wrap(err, "Training", "Could not train the predictor")
 	}
+	return nil
+}
 
+// Evaluates the trained models on the created datasets
+func (p *Processor) Evaluate() errors.Error {
+	log.Info("Evaluate...\n")
+	if err := dataset.Evaluate(configuration.MethodGenerator); err != nil {
+		return errors.Wrap(err, "Evaluation", "Could not evaluate datasets")
+	}
+	return nil
 }
 
-// Executes the training process
-func (p *Processor) train() errors.Error {
-	/*if err := trainReturnTypes(); err != nil {
-		return err
-	}*/
-	return p.trainMethods()
+// Rates the stored generated methods of the evaluated models again using the current evaluation configuration
+func (p *Processor) Rescore() errors.Error {
+	log.Info("Rescore...\n")
+	if err := dataset.Rescore(configuration.MethodGenerator); err != nil {
+		return errors.Wrap(err, "Evaluation", "Could not rescore datasets")
+	}
+	return nil
 }
 
 func (p *Processor) trainReturnTypes() errors.Error {
@@ -249,7 +308,7 @@ func (p *Processor) trainMethods() errors.Error {
 }
 
 // Logs any problems occured during dataset creation
-func (p *Processor) logProblems() {
+func (p *Processor) LogProblems() {
 	problems := log.GetProblems()
 	if len(problems) > 0 {
 		log.Info("During the dataset creation the following problems occured which may have influence on the quality and completeness of the resulting dataset:\n")
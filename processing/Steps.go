@@ -0,0 +1,299 @@
+package processing
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/processing/dataset"
+	"returntypes-langserver/processing/dataset/manifest"
+	"returntypes-langserver/processing/extractor"
+	"returntypes-langserver/processing/git"
+	"returntypes-langserver/processing/pipeline"
+	"returntypes-langserver/processing/projects"
+)
+
+const (
+	CloneStep    = "clone"
+	CrawlStep    = "crawl"
+	ExtractStep  = "extract"
+	ExcelStep    = "excel"
+	DatasetStep  = "dataset"
+	TrainStep    = "train"
+	EvaluateStep = "evaluate"
+)
+
+// The directory (inside of the main output directory) containing the checkpoints of the completed steps
+const CheckpointDirName = "checkpoints"
+
+// Returns the steps of the dataset creation process.
+func (p *Processor) Steps() []pipeline.Step {
+	return []pipeline.Step{
+		{
+			Name:        CloneStep,
+			Run:         p.Clone,
+			Plan:        p.planClone,
+			Fingerprint: p.cloneFingerprint,
+			Outputs:     p.cloneOutputs,
+			IsDisabled:  configuration.ClonerSkip,
+			IsForced:    pipeline.Never,
+		},
+		{
+			Name:        CrawlStep,
+			DependsOn:   []string{CloneStep},
+			Run:         p.PreprocessJavaCode,
+			Plan:        p.planCrawl,
+			Fingerprint: p.crawlFingerprint,
+			Outputs:     p.crawlOutputs,
+			IsDisabled:  pipeline.Never,
+			IsForced:    pipeline.Never,
+		},
+		{
+			Name:        ExtractStep,
+			DependsOn:   []string{CrawlStep},
+			Run:         p.CreateBasicData,
+			Plan:        p.planExtraction,
+			Fingerprint: p.extractionFingerprint,
+			Outputs:     extractionOutputs,
+			IsDisabled:  pipeline.Never,
+			IsForced:    configuration.ForceExtraction,
+		},
+		{
+			Name:        ExcelStep,
+			DependsOn:   []string{ExtractStep},
+			Run:         p.CreateExcelOutput,
+			Plan:        p.planExcelOutput,
+			Fingerprint: excelOutputFingerprint,
+			Outputs:     excelOutputs,
+			IsDisabled:  pipeline.Never,
+			IsForced:    pipeline.Never,
+		},
+		{
+			Name:        DatasetStep,
+			DependsOn:   []string{ExtractStep},
+			Run:         p.CreateDataset,
+			Plan:        p.planDatasets,
+			Fingerprint: datasetFingerprint,
+			Outputs:     datasetOutputs,
+			IsDisabled:  pipeline.Never,
+			IsForced:    pipeline.Never,
+		},
+		{
+			Name:        TrainStep,
+			DependsOn:   []string{DatasetStep},
+			Run:         p.Train,
+			Plan:        p.planTraining,
+			Fingerprint: trainingFingerprint,
+			Outputs:     trainingOutputs,
+			IsDisabled:  configuration.PredictorSkipTraining,
+			IsForced:    pipeline.Never,
+		},
+		{
+			Name:        EvaluateStep,
+			DependsOn:   []string{TrainStep},
+			Run:         p.Evaluate,
+			Plan:        p.planEvaluation,
+			Fingerprint: evaluationFingerprint,
+			Outputs:     evaluationOutputs,
+			IsDisabled:  configuration.PredictorSkipTraining,
+			IsForced:    pipeline.Never,
+		},
+	}
+}
+
+// Returns the graph of the dataset creation steps. The checkpoints are stored in the main output directory.
+func (p *Processor) Graph() (*pipeline.Graph, errors.Error) {
+	return pipeline.NewGraph(filepath.Join(configuration.MainOutputDir(), CheckpointDirName), p.Steps())
+}
+
+func (p *Processor) cloneFingerprint() (string, errors.Error) {
+	return manifest.HashValues(p.mapProjectsToRepositoryList(p.projects), configuration.ClonerOutputDir()), nil
+}
+
+// Returns the directories of the cloned repositories. Repositories which could not be cloned are missing, so the
+// cloning is retried on the next run.
+func (p *Processor) cloneOutputs() []string {
+	repositories := p.mapProjectsToRepositoryList(p.projects)
+	outputs := make([]string, len(repositories))
+	for i, repository := range repositories {
+		outputs[i] = filepath.Join(configuration.ClonerOutputDir(), repository.DirName)
+	}
+	return outputs
+}
+
+// The crawler outputs depend on the checked out commits of the projects.
+func (p *Processor) crawlFingerprint() (string, errors.Error) {
+	return manifest.HashValues(projects.MapConfigurationProjects(p.projects), manifest.ProjectSources(p.projects), configuration.CrawlerDefaultJavaVersion()), nil
+}
+
+// Returns the crawler output files of all existing projects.
+func (p *Processor) crawlOutputs() []string {
+	outputs := make([]string, 0, len(p.projects))
+	for _, project := range p.projects {
+		if utils.DirExists(project.ExpectedDirectoryPath()) {
+			outputs = append(outputs, extractor.GetPreprocessedFilePathForProject(project))
+		}
+	}
+	return outputs
+}
+
+func (p *Processor) extractionFingerprint() (string, errors.Error) {
+	files := append(extractor.GetPreprocessedFilePathForProjects(p.projects), configuration.DefaultLibraries()...)
+	return manifest.HashValues(pipeline.HashFileStates(files...), configuration.DependenciesResolve(), configuration.DependenciesTransitive()), nil
+}
+
+func extractionOutputs() []string {
+	return []string{configuration.MethodsWithReturnTypesOutputPath(), configuration.ClassHierarchyOutputPath(), configuration.FileContextTypesOutputPath()}
+}
+
+func excelOutputFingerprint() (string, errors.Error) {
+	return manifest.HashValues(pipeline.HashFileStates(configuration.MethodsWithReturnTypesOutputPath()), configuration.ExcelSets(), configuration.CreateMethodOutputPerProject()), nil
+}
+
+func excelOutputs() []string {
+	return []string{configuration.MethodsWithReturnTypesExcelOutputDir()}
+}
+
+func datasetFingerprint() (string, errors.Error) {
+	files := append(extractionOutputs(), configuration.DefaultLibraries()...)
+	return manifest.HashValues(
+		pipeline.HashFileStates(files...),
+		configuration.Datasets(),
+		configuration.DatasetPrefix(),
+		configuration.SkipIfOutputExists(),
+		configuration.DeduplicationActive(),
+		configuration.DeduplicationThreshold(),
+	), nil
+}
+
+func datasetOutputs() []string {
+	return []string{configuration.DatasetOutputDir()}
+}
+
+func trainingFingerprint() (string, errors.Error) {
+	return manifest.HashValues(configuration.Datasets(), configuration.DatasetPrefix(), configuration.ContinueTraining()), nil
+}
+
+func evaluationFingerprint() (string, errors.Error) {
+	return manifest.HashValues(configuration.Datasets(), configuration.DatasetPrefix(), configuration.EvaluationSubsets()), nil
+}
+
+// Returns the dataset configurations saved for the trained models.
+func trainingOutputs() []string {
+	return dataset.TrainingOutputs(configuration.MethodGenerator)
+}
+
+func evaluationOutputs() []string {
+	return dataset.EvaluationOutputs(configuration.MethodGenerator)
+}
+
+func (p *Processor) planClone() ([]pipeline.Action, errors.Error) {
+	repositories := p.mapProjectsToRepositoryList(p.projects)
+	actions := make([]pipeline.Action, 0, len(repositories))
+	for _, repository := range repositories {
+		cloned, err := git.IsAlreadyCloned(repository.DirName)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, pipeline.Action{
+			Description: fmt.Sprintf("Clone %s to %s", repository.Url, filepath.Join(configuration.ClonerOutputDir(), repository.DirName)),
+			IsSkipped:   cloned,
+		})
+	}
+	return actions, nil
+}
+
+func (p *Processor) planCrawl() ([]pipeline.Action, errors.Error) {
+	actions := make([]pipeline.Action, 0, len(p.projects))
+	for _, project := range p.projects {
+		required, err := extractor.IsPreprocessingRequired(project, p.getPreviousProjectStateFor(project))
+		if err != nil {
+			return nil, err
+		}
+		action := pipeline.Action{
+			Description: fmt.Sprintf("Crawl %s to %s", project.ExpectedDirectoryPath(), extractor.GetPreprocessedFilePathForProject(project)),
+			IsSkipped:   !required,
+		}
+		if required && !utils.DirExists(project.ExpectedDirectoryPath()) {
+			action.Description = fmt.Sprintf("Crawl %s (the project does not exist at %s)", project.Name(), project.ExpectedDirectoryPath())
+			action.IsSkipped = true
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func (p *Processor) planExtraction() ([]pipeline.Action, errors.Error) {
+	crawlActions, err := p.planCrawl()
+	if err != nil {
+		return nil, err
+	}
+	return []pipeline.Action{{
+		Description: fmt.Sprintf("Extract methods of %d projects to %s and %s", len(p.projects), configuration.MethodsWithReturnTypesOutputPath(), configuration.ClassHierarchyOutputPath()),
+		IsSkipped:   !p.isExtractionProcessRequired() && pipeline.PendingActions(crawlActions) == 0,
+	}}, nil
+}
+
+func (p *Processor) planExcelOutput() ([]pipeline.Action, errors.Error) {
+	actions := []pipeline.Action{{
+		Description: fmt.Sprintf("Write %d excel sets to %s", len(configuration.ExcelSets()), configuration.MethodsWithReturnTypesExcelOutputDir()),
+	}}
+	if configuration.CreateMethodOutputPerProject() {
+		for _, project := range p.projects {
+			path := filepath.Join(configuration.MethodsWithReturnTypesExcelOutputDir(), "project-output", project.Name()+".xlsx")
+			actions = append(actions, pipeline.Action{
+				Description: fmt.Sprintf("Write methods of %s to %s", project.Name(), path),
+				IsSkipped:   utils.FileExists(path),
+			})
+		}
+	}
+	return actions, nil
+}
+
+func (p *Processor) planDatasets() ([]pipeline.Action, errors.Error) {
+	extractionActions, err := p.planExtraction()
+	if err != nil {
+		return nil, err
+	} else if pipeline.PendingActions(extractionActions) > 0 {
+		// The inputs of the datasets are not known before the extraction
+		return []pipeline.Action{{Description: fmt.Sprintf("Create or update the configured datasets in %s after the extraction", configuration.DatasetOutputDir())}}, nil
+	}
+
+	states, err := dataset.DatasetStates(configuration.MethodGenerator, configuration.MethodsWithReturnTypesOutputPath(), configuration.ClassHierarchyOutputPath())
+	if err != nil {
+		return nil, err
+	}
+	actions := make([]pipeline.Action, 0, len(states))
+	for _, state := range states {
+		action := pipeline.Action{Description: fmt.Sprintf("Create %s at %s", state.Dataset.QualifiedIdentifier(), state.Path)}
+		switch {
+		case !configuration.SkipIfOutputExists() && state.State != dataset.OutputMissing:
+			action.Description = fmt.Sprintf("Recreate %s at %s", state.Dataset.QualifiedIdentifier(), state.Path)
+		case state.State == dataset.OutputStale:
+			action.Description = fmt.Sprintf("Rebuild %s at %s as it's inputs have changed", state.Dataset.QualifiedIdentifier(), state.Path)
+		case state.State == dataset.OutputUpToDate:
+			action.IsSkipped = true
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func (p *Processor) planTraining() ([]pipeline.Action, errors.Error) {
+	return planForTrainingTargets("Train the predictor on %s"), nil
+}
+
+func (p *Processor) planEvaluation() ([]pipeline.Action, errors.Error) {
+	return planForTrainingTargets("Evaluate the predictor on %s"), nil
+}
+
+func planForTrainingTargets(format string) []pipeline.Action {
+	targets := dataset.TrainingTargets(configuration.MethodGenerator)
+	actions := make([]pipeline.Action, len(targets))
+	for i, target := range targets {
+		actions[i] = pipeline.Action{Description: fmt.Sprintf(format, target)}
+	}
+	return actions
+}
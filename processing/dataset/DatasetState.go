@@ -0,0 +1,90 @@
+package dataset
+
+import (
+	"path/filepath"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/processing/dataset/manifest"
+)
+
+// The state of a dataset's output compared to the current inputs
+type OutputState string
+
+const (
+	// The dataset was not created yet
+	OutputMissing OutputState = "missing"
+	// The dataset exists and was created from the current inputs (or before manifests were written)
+	OutputUpToDate OutputState = "up to date"
+	// The dataset exists, but it's inputs have changed since it was created
+	OutputStale OutputState = "stale"
+)
+
+type DatasetState struct {
+	Dataset configuration.Dataset
+	Path    string
+	State   OutputState
+}
+
+// Returns the state of each configured dataset and it's subsets without creating them.
+func DatasetStates(modelType configuration.ModelType, methodsWithReturnTypesPath, classHierarchyPath string) ([]DatasetState, errors.Error) {
+	inputs, err := loadInputs(modelType, methodsWithReturnTypesPath, classHierarchyPath)
+	if err != nil {
+		return nil, err
+	}
+	return collectDatasetStates(modelType, configuration.DatasetOutputDir(), configuration.Datasets(), inputs), nil
+}
+
+func collectDatasetStates(modelType configuration.ModelType, path string, datasets []configuration.Dataset, inputs manifest.Inputs) []DatasetState {
+	states := make([]DatasetState, 0, len(datasets))
+	for _, set := range datasets {
+		setPath := getPathForDataset(path, set)
+		setInputs := withDatasetConfiguration(inputs, set, modelType)
+		states = append(states, DatasetState{
+			Dataset: set,
+			Path:    setPath,
+			State:   getOutputState(setPath, setInputs),
+		})
+		for _, subset := range set.Subsets {
+			states = append(states, collectDatasetStates(modelType, setPath, []configuration.Dataset{inheritOptions(set, subset)}, setInputs)...)
+		}
+	}
+	return states
+}
+
+func getOutputState(path string, inputs manifest.Inputs) OutputState {
+	if !utils.DirExists(path) {
+		return OutputMissing
+	} else if !utils.FileExists(filepath.Join(path, manifest.FileName)) {
+		return OutputUpToDate
+	} else if manifest.IsStale(path, inputs) {
+		return OutputStale
+	}
+	return OutputUpToDate
+}
+
+// Returns the qualified identifiers of all datasets (including alternatives and subsets) which are trained and evaluated
+// for the model type in the order they are trained.
+func TrainingTargets(modelType configuration.ModelType) []string {
+	return collectTrainingTargets(modelType, configuration.Datasets())
+}
+
+func collectTrainingTargets(modelType configuration.ModelType, datasets []configuration.Dataset) []string {
+	targets := make([]string, 0, len(datasets))
+	for _, dataset := range datasets {
+		if !acceptsModelType(modelType, dataset.TargetModels) {
+			continue
+		}
+
+		targets = append(targets, dataset.QualifiedIdentifier())
+		for _, alternative := range dataset.Alternatives {
+			if acceptsModelType(modelType, alternative.TargetModels) {
+				set := dataset
+				set.DatasetBase = alternative
+				targets = append(targets, set.QualifiedIdentifier())
+			}
+		}
+		targets = append(targets, collectTrainingTargets(modelType, dataset.Subsets)...)
+	}
+	return targets
+}
@@ -8,11 +8,14 @@ import (
 	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/common/dataformat/csv"
 	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
 	"returntypes-langserver/common/metrics"
 	"returntypes-langserver/processing/dataset/base"
+	"returntypes-langserver/processing/dataset/manifest"
 	"returntypes-langserver/processing/dataset/methodgeneration"
 	"returntypes-langserver/processing/dataset/returntypesvalidation"
 	"returntypes-langserver/services/predictor"
+	"time"
 )
 
 // Common dataset processor which "preprocesses" the data (like applying common filters and so on)
@@ -21,6 +24,11 @@ type DatasetProcessor struct {
 	TargetSet      configuration.Dataset
 	SubProcessors  []DatasetProcessor
 	tree           *packagetree.Tree
+	path           string
+	// The inputs the dataset is created from, which are recorded in the manifest
+	inputs manifest.Inputs
+	// True if the existing dataset was created from different inputs
+	isStale bool
 }
 
 // Helper type which passes the calls to all slice elements
@@ -44,18 +52,27 @@ func (p *DatasetProcessors) Close() errors.Error {
 	return nil
 }
 
-func NewProcessor(set configuration.Dataset, modelType configuration.ModelType, path string, tree *packagetree.Tree) (DatasetProcessor, errors.Error) {
+// Creates a processor for the dataset and it's subsets. The inputs should contain the dataset configuration hash of
+// the parent dataset (if any), so changes of the parent configuration also invalidate the subsets.
+func NewProcessor(set configuration.Dataset, modelType configuration.ModelType, path string, tree *packagetree.Tree, inputs manifest.Inputs) (DatasetProcessor, errors.Error) {
 	path = filepath.Join(path, set.Name())
+	inputs = withDatasetConfiguration(inputs, set, modelType)
 	processor := DatasetProcessor{
 		TargetSet:     set,
 		SubProcessors: make([]DatasetProcessor, 0, len(set.Subsets)),
+		path:          path,
+		inputs:        inputs,
 	}
 	if err := processor.initializeModelProcessor(modelType, path, tree); err != nil {
 		return processor, err
 	}
+	if processor.ModelProcessor != nil && processor.ModelProcessor.CanBeSkipped() && manifest.IsStale(path, inputs) {
+		log.Info("Rebuild dataset %s because it's inputs have changed.\n", set.QualifiedIdentifier())
+		processor.isStale = true
+	}
 
 	for _, subset := range set.Subsets {
-		if subprocessor, err := NewProcessor(inheritOptions(set, subset), modelType, path, tree); err != nil {
+		if subprocessor, err := NewProcessor(inheritOptions(set, subset), modelType, path, tree, inputs); err != nil {
 			return processor, err
 		} else if !subprocessor.CanBeSkipped() {
 			processor.SubProcessors = append(processor.SubProcessors, subprocessor)
@@ -64,10 +81,19 @@ func NewProcessor(set configuration.Dataset, modelType configuration.ModelType,
 	return processor, nil
 }
 
+// Returns the inputs including the hash of the dataset configuration.
+func withDatasetConfiguration(inputs manifest.Inputs, set configuration.Dataset, modelType configuration.ModelType) manifest.Inputs {
+	inputs.DatasetConfigurationHash = manifest.HashValues(inputs.DatasetConfigurationHash, modelType, set.Filter, set.CreationOptions, set.PreprocessingOptions)
+	return inputs
+}
+
 func (p *DatasetProcessor) CanBeSkipped() bool {
 	if !configuration.SkipIfOutputExists() {
 		return false
 	}
+	if p.isStale {
+		return false
+	}
 	if p.ModelProcessor != nil && !p.ModelProcessor.CanBeSkipped() {
 		return false
 	}
@@ -85,7 +111,7 @@ func (p *DatasetProcessor) initializeModelProcessor(modelType configuration.Mode
 	case configuration.ReturnTypesValidator:
 		processor, err = returntypesvalidation.NewProcessor(path, p.TargetSet.CreationOptions, tree)
 	case configuration.MethodGenerator:
-		processor, err = methodgeneration.NewProcessor(path, p.TargetSet.CreationOptions, tree)
+		processor, err = methodgeneration.NewProcessor(path, p.TargetSet, tree)
 	}
 	p.ModelProcessor = processor
 	return err
@@ -116,6 +142,8 @@ func (p *DatasetProcessor) Close() errors.Error {
 	if p.ModelProcessor != nil {
 		if err := p.ModelProcessor.Close(); err != nil {
 			return err
+		} else if err := p.saveManifest(); err != nil {
+			return err
 		}
 	}
 	for i := range p.SubProcessors {
@@ -126,14 +154,27 @@ func (p *DatasetProcessor) Close() errors.Error {
 	return nil
 }
 
+func (p *DatasetProcessor) saveManifest() errors.Error {
+	datasetManifest := manifest.Manifest{
+		DatasetName: p.TargetSet.QualifiedIdentifier(),
+		ToolVersion: manifest.ToolVersion,
+		CreatedAt:   time.Now(),
+		Inputs:      p.inputs,
+		Splits:      p.ModelProcessor.SplitSizes(),
+	}
+	return datasetManifest.Save(filepath.Join(p.path, manifest.FileName))
+}
+
 func (p *DatasetProcessor) isIncluded(method csv.Method) (bool, errors.Error) {
 	if !csv.IsMethodIncluded(method, p.TargetSet.Filter) {
 		return false, nil
 	}
 	if p.TargetSet.CreationOptions.MaxTokensPerOutputSequence != 0 {
-		parameters, err := java.ParseParameterList(method.Parameters)
+		// The types are counted as they are rendered in the method generation dataset
+		rendering := p.TargetSet.CreationOptions.TypeRendering
+		parameters, err := java.ParseParameterList(methodgeneration.RenderParameterTypes(method.Parameters, rendering))
 		if err == nil {
-			outputSequence := p.getOutputSequence(parameters, method.ReturnType)
+			outputSequence := p.getOutputSequence(parameters, methodgeneration.RenderTypeName(method.ReturnType, rendering))
 			tokens := metrics.TokenizeSentence(predictor.SplitMethodNameToSentence(outputSequence))
 			if len(tokens) > p.TargetSet.CreationOptions.MaxTokensPerOutputSequence {
 				return false, nil
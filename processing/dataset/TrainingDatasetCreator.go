@@ -10,10 +10,16 @@ import (
 	"returntypes-langserver/common/debug/log"
 	"returntypes-langserver/common/utils"
 	"returntypes-langserver/processing/dataset/base"
+	"returntypes-langserver/processing/dataset/manifest"
 	"returntypes-langserver/processing/dataset/methodgeneration"
 	"returntypes-langserver/processing/dataset/returntypesvalidation"
+	"returntypes-langserver/processing/deduplication"
+	"returntypes-langserver/processing/projects"
+	"returntypes-langserver/processing/statistics"
 )
 
+const DeduplicationStatisticsFileName = "Deduplication.xlsx"
+
 // Creates a training and an evaluation set.
 func CreateTrainingAndEvaluationSet(modelType configuration.ModelType, methodsWithReturnTypesPath, classHierarchyPath string) errors.Error {
 	if len(configuration.Datasets()) == 0 {
@@ -24,10 +30,14 @@ func CreateTrainingAndEvaluationSet(modelType configuration.ModelType, methodsWi
 	if methods, classes, err := loadMethodsAndClasses(methodsWithReturnTypesPath, classHierarchyPath); err != nil {
 		return err
 	} else {
+		inputs, err := loadInputs(modelType, methodsWithReturnTypesPath, classHierarchyPath)
+		if err != nil {
+			return err
+		}
 		processors := make(DatasetProcessors, 0, len(configuration.Datasets()))
 		tree := createPackageTree(classes)
 		for _, dataset := range configuration.Datasets() {
-			if processor, err := NewProcessor(dataset, modelType, configuration.DatasetOutputDir(), tree); err != nil {
+			if processor, err := NewProcessor(dataset, modelType, configuration.DatasetOutputDir(), tree, inputs); err != nil {
 				return err
 			} else if !processor.CanBeSkipped() {
 				processors = append(processors, processor)
@@ -36,6 +46,11 @@ func CreateTrainingAndEvaluationSet(modelType configuration.ModelType, methodsWi
 		if len(processors) == 0 {
 			return nil
 		}
+		if configuration.DeduplicationActive() {
+			if methods, err = removeNearDuplicates(methods); err != nil {
+				return err
+			}
+		}
 
 		for _, method := range methods {
 			if err := processors.Process(method); err != nil {
@@ -46,6 +61,38 @@ func CreateTrainingAndEvaluationSet(modelType configuration.ModelType, methodsWi
 	}
 }
 
+// Removes near-duplicate methods of the corpus and writes the statistics about the removed methods if activated.
+func removeNearDuplicates(methods []csv.Method) ([]csv.Method, errors.Error) {
+	log.Info("Remove near-duplicate methods...\n")
+	deduplicated, report := deduplication.Deduplicate(methods, projects.GetProjects(), deduplication.Options{
+		Threshold: configuration.DeduplicationThreshold(),
+	})
+	log.Info("Removed %d near-duplicate methods of %d methods.\n", report.RemovedCount(), len(methods))
+	if configuration.CreateStatistics() {
+		if err := statistics.CreateDeduplicationStatistics(report, filepath.Join(configuration.DatasetOutputDir(), DeduplicationStatisticsFileName)); err != nil {
+			return nil, err
+		}
+	}
+	return deduplicated, nil
+}
+
+// Returns the inputs all datasets are created from. The dataset configuration hash is set by each dataset processor.
+func loadInputs(modelType configuration.ModelType, methodsWithReturnTypesPath, classHierarchyPath string) (manifest.Inputs, errors.Error) {
+	files, err := manifest.HashFiles(append([]string{methodsWithReturnTypesPath, classHierarchyPath}, configuration.DefaultLibraries()...)...)
+	if err != nil {
+		return manifest.Inputs{}, err
+	}
+	return manifest.Inputs{
+		Projects: manifest.ProjectSources(projects.GetProjects()),
+		Files:    files,
+		GlobalConfigurationHash: manifest.HashValues(
+			modelType,
+			configuration.DeduplicationActive(),
+			configuration.DeduplicationThreshold(),
+		),
+	}, nil
+}
+
 // Loads the methods and class data into the creator
 func loadMethodsAndClasses(methodsWithReturnTypesPath, classHierarchyPath string) ([]csv.Method, []csv.Class, errors.Error) {
 	methodsRecords, err := csv.NewFileReader(methodsWithReturnTypesPath).ReadMethodRecords()
@@ -136,29 +183,62 @@ func getTrainerByModelType(modelType configuration.ModelType, dataset configurat
 	}
 }
 
+// Returns the files written by the training of the datasets accepting the model type. Only the method generator writes
+// files, the models are saved by the predictor.
+func TrainingOutputs(modelType configuration.ModelType) []string {
+	outputs := make([]string, 0)
+	if modelType == configuration.MethodGenerator {
+		forEachDataset(modelType, configuration.DatasetOutputDir(), configuration.Datasets(), func(modelType configuration.ModelType, path string, dataset configuration.Dataset) errors.Error {
+			outputs = append(outputs, methodgeneration.TrainingOutputs(path, dataset)...)
+			return nil
+		})
+	}
+	return outputs
+}
+
+// Returns the result files written by the evaluation of the datasets accepting the model type.
+func EvaluationOutputs(modelType configuration.ModelType) []string {
+	outputs := make([]string, 0)
+	if modelType == configuration.MethodGenerator {
+		forEachDataset(modelType, configuration.DatasetOutputDir(), configuration.Datasets(), func(modelType configuration.ModelType, path string, dataset configuration.Dataset) errors.Error {
+			outputs = append(outputs, methodgeneration.EvaluationOutputs(path, dataset)...)
+			return nil
+		})
+	}
+	return outputs
+}
+
 func Evaluate(modelType configuration.ModelType) errors.Error {
-	return evaluateDatasets(modelType, configuration.DatasetOutputDir(), configuration.Datasets())
+	return forEachDataset(modelType, configuration.DatasetOutputDir(), configuration.Datasets(), evaluate)
 }
 
-func evaluateDatasets(modelType configuration.ModelType, path string, datasets []configuration.Dataset) errors.Error {
+// Rates the outputs of the previous evaluations again using the current evaluation configuration.
+func Rescore(modelType configuration.ModelType) errors.Error {
+	return forEachDataset(modelType, configuration.DatasetOutputDir(), configuration.Datasets(), rescore)
+}
+
+// Calls the function for each dataset, its alternatives and its subsets which accept the model type.
+func forEachDataset(modelType configuration.ModelType, path string, datasets []configuration.Dataset,
+	fn func(modelType configuration.ModelType, path string, dataset configuration.Dataset) errors.Error) errors.Error {
 	for _, dataset := range datasets {
 		if !acceptsModelType(modelType, dataset.TargetModels) {
 			continue
 		}
 
 		path := getPathForDataset(path, dataset)
-		if err := evaluate(modelType, path, dataset); err != nil {
+		if err := fn(modelType, path, dataset); err != nil {
 			return err
-		} else if err := evaluateAlternatives(modelType, path, dataset); err != nil {
+		} else if err := forEachAlternative(modelType, path, dataset, fn); err != nil {
 			return err
-		} else if err := evaluateDatasets(modelType, path, dataset.Subsets); err != nil {
+		} else if err := forEachDataset(modelType, path, dataset.Subsets, fn); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func evaluateAlternatives(modelType configuration.ModelType, path string, dataset configuration.Dataset) errors.Error {
+func forEachAlternative(modelType configuration.ModelType, path string, dataset configuration.Dataset,
+	fn func(modelType configuration.ModelType, path string, dataset configuration.Dataset) errors.Error) errors.Error {
 	for _, alternative := range dataset.Alternatives {
 		if !acceptsModelType(modelType, alternative.TargetModels) {
 			continue
@@ -166,7 +246,7 @@ func evaluateAlternatives(modelType configuration.ModelType, path string, datase
 
 		set := dataset
 		set.DatasetBase = alternative
-		if err := evaluate(modelType, path, set); err != nil {
+		if err := fn(modelType, path, set); err != nil {
 			return err
 		}
 	}
@@ -182,6 +262,18 @@ func evaluate(modelType configuration.ModelType, path string, dataset configurat
 	return nil
 }
 
+func rescore(modelType configuration.ModelType, path string, dataset configuration.Dataset) errors.Error {
+	evaluator, err := getEvaluatorByModelType(modelType, dataset)
+	if err != nil {
+		return err
+	}
+	rescorer, ok := evaluator.(base.Rescorer)
+	if !ok {
+		return errors.New("Evaluation", "Rescoring is not supported for the model type %s", modelType)
+	}
+	return rescorer.Rescore(path)
+}
+
 func getEvaluatorByModelType(modelType configuration.ModelType, dataset configuration.Dataset) (base.Evaluator, errors.Error) {
 	switch modelType {
 	case configuration.MethodGenerator:
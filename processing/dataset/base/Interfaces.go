@@ -9,6 +9,11 @@ type Evaluator interface {
 	Evaluate(path string) errors.Error
 }
 
+// Rates the stored outputs of a previous evaluation again without the predictor
+type Rescorer interface {
+	Rescore(path string) errors.Error
+}
+
 type Trainer interface {
 	Train(path string) errors.Error
 }
@@ -18,4 +23,6 @@ type MethodProcessor interface {
 	Process(*csv.Method) (bool, errors.Error)
 	CanBeSkipped() bool
 	Close() errors.Error
+	// Returns the number of rows of each written dataset file by the file name
+	SplitSizes() map[string]int
 }
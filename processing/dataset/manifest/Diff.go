@@ -0,0 +1,97 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// A difference between two manifests. Old or New are empty if the value does only exist in one of the manifests.
+type Difference struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (d Difference) String() string {
+	switch {
+	case d.Old == "":
+		return fmt.Sprintf("+ %s: %s", d.Field, d.New)
+	case d.New == "":
+		return fmt.Sprintf("- %s: %s", d.Field, d.Old)
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", d.Field, d.Old, d.New)
+}
+
+// Returns the differences between the manifests a and b.
+func Diff(a, b Manifest) []Difference {
+	differences := make([]Difference, 0)
+	differences = appendIfDifferent(differences, "datasetName", a.DatasetName, b.DatasetName)
+	differences = appendIfDifferent(differences, "toolVersion", a.ToolVersion, b.ToolVersion)
+	differences = appendIfDifferent(differences, "createdAt", formatTime(a.CreatedAt), formatTime(b.CreatedAt))
+	differences = append(differences, DiffInputs(a.Inputs, b.Inputs)...)
+	return append(differences, diffMaps("splits", countsToStrings(a.Splits), countsToStrings(b.Splits))...)
+}
+
+// Returns the differences between the inputs a and b. If there are no differences, datasets created from a and b are equal.
+func DiffInputs(a, b Inputs) []Difference {
+	differences := make([]Difference, 0)
+	differences = append(differences, diffProjects(a.Projects, b.Projects)...)
+	differences = append(differences, diffMaps("files", a.Files, b.Files)...)
+	differences = appendIfDifferent(differences, "globalConfigurationHash", a.GlobalConfigurationHash, b.GlobalConfigurationHash)
+	return appendIfDifferent(differences, "datasetConfigurationHash", a.DatasetConfigurationHash, b.DatasetConfigurationHash)
+}
+
+func diffProjects(a, b []ProjectSource) []Difference {
+	projectsA, projectsB := make(map[string]string), make(map[string]string)
+	for _, project := range a {
+		projectsA[project.Name] = project.String()
+	}
+	for _, project := range b {
+		projectsB[project.Name] = project.String()
+	}
+	return diffMaps("projects", projectsA, projectsB)
+}
+
+// Compares the values of both maps by their keys. The differences are sorted by the keys.
+func diffMaps(field string, a, b map[string]string) []Difference {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	differences := make([]Difference, 0)
+	for _, key := range keys {
+		differences = appendIfDifferent(differences, field+"."+key, a[key], b[key])
+	}
+	return differences
+}
+
+func appendIfDifferent(differences []Difference, field, a, b string) []Difference {
+	if a != b {
+		return append(differences, Difference{Field: field, Old: a, New: b})
+	}
+	return differences
+}
+
+func countsToStrings(counts map[string]int) map[string]string {
+	values := make(map[string]string, len(counts))
+	for key, count := range counts {
+		values[key] = strconv.Itoa(count)
+	}
+	return values
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,159 @@
+// The manifest package records the provenance of dataset folders: the source projects (including their commits),
+// the input files, hashes of the configuration used and the row counts of the written files. If the inputs of a
+// dataset change, the dataset is considered stale and is rebuilt instead of skipped.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/processing/git"
+	"returntypes-langserver/processing/projects"
+	"time"
+)
+
+const FileName = "manifest.json"
+
+// The version of the tool which created the dataset. May be set at build time using
+// -ldflags "-X returntypes-langserver/processing/dataset/manifest.ToolVersion=<version>"
+var ToolVersion = "dev"
+
+var ErrManifest = errors.ErrorId("Manifest", "Could not process dataset manifest")
+
+type Manifest struct {
+	DatasetName string    `json:"datasetName"`
+	ToolVersion string    `json:"toolVersion"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Inputs      Inputs    `json:"inputs"`
+	// The number of rows of each written dataset file
+	Splits map[string]int `json:"splits"`
+}
+
+// The inputs a dataset is created from. If any of them changes, the dataset is stale.
+type Inputs struct {
+	Projects []ProjectSource `json:"projects"`
+	// Hashes of the input files (like the extracted methods and the class hierarchy) by their file name
+	Files map[string]string `json:"files"`
+	// Hash of the configuration options which affect all datasets (like the deduplication)
+	GlobalConfigurationHash string `json:"globalConfigurationHash"`
+	// Hash of the dataset configuration including the configuration of it's parent datasets
+	DatasetConfigurationHash string `json:"datasetConfigurationHash"`
+}
+
+type ProjectSource struct {
+	Name   string `json:"name"`
+	GitUri string `json:"gitUri,omitempty"`
+	// The commit checked out while the dataset was created. Empty if the project is not a git repository.
+	Commit string `json:"commit,omitempty"`
+}
+
+func (p ProjectSource) String() string {
+	commit := p.Commit
+	if commit == "" {
+		commit = "(no commit)"
+	}
+	if p.GitUri == "" {
+		return commit
+	}
+	return p.GitUri + "@" + commit
+}
+
+func Load(path string) (Manifest, errors.Error) {
+	var manifest Manifest
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return manifest, ErrManifest.Wrap(err)
+	} else if err := json.Unmarshal(contents, &manifest); err != nil {
+		return manifest, ErrManifest.Wrap(err)
+	}
+	return manifest, nil
+}
+
+func (m Manifest) Save(path string) errors.Error {
+	contents, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return ErrManifest.Wrap(err)
+	}
+	file, createErr := utils.CreateFile(path)
+	if createErr != nil {
+		return createErr
+	}
+	defer file.Close()
+	if _, err := file.Write(contents); err != nil {
+		return ErrManifest.Wrap(err)
+	}
+	return nil
+}
+
+// Returns true if the manifest in the dataset directory was created with different inputs. Datasets without a
+// manifest (created by older versions) are not considered stale.
+func IsStale(datasetDir string, inputs Inputs) bool {
+	path := filepath.Join(datasetDir, FileName)
+	if !utils.FileExists(path) {
+		return false
+	}
+	manifest, err := Load(path)
+	if err != nil {
+		return true
+	}
+	return len(DiffInputs(manifest.Inputs, inputs)) > 0
+}
+
+// Returns the source of each project with the commit currently checked out.
+func ProjectSources(projectList []projects.Project) []ProjectSource {
+	sources := make([]ProjectSource, len(projectList))
+	for i, project := range projectList {
+		sources[i] = ProjectSource{
+			Name:   project.Name(),
+			GitUri: project.GitUri,
+		}
+		if commit, err := git.HeadCommit(project.ExpectedDirectoryPath()); err == nil {
+			sources[i].Commit = commit
+		}
+	}
+	return sources
+}
+
+// Returns the hashes of the files by their file names.
+func HashFiles(paths ...string) (map[string]string, errors.Error) {
+	hashes := make(map[string]string, len(paths))
+	for _, path := range paths {
+		if hash, err := HashFile(path); err != nil {
+			return nil, err
+		} else {
+			hashes[filepath.Base(path)] = hash
+		}
+	}
+	return hashes, nil
+}
+
+// Returns the hex encoded SHA-256 hash of the file contents.
+func HashFile(path string) (string, errors.Error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", ErrManifest.Wrap(err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", ErrManifest.Wrap(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Returns the hex encoded SHA-256 hash of the JSON representation of the values.
+func HashValues(values ...interface{}) string {
+	hash := sha256.New()
+	encoder := json.NewEncoder(hash)
+	for _, value := range values {
+		// Values passed here are configuration structs which are always encodable
+		encoder.Encode(value)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,58 @@
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffManifests(t *testing.T) {
+	// given
+	a := Manifest{
+		DatasetName: "set",
+		Inputs: Inputs{
+			Projects: []ProjectSource{{Name: "a", Commit: "1"}, {Name: "b", Commit: "2"}},
+			Files:    map[string]string{"methods.csv": "x"},
+		},
+		Splits: map[string]int{"trainingSet.csv": 10},
+	}
+	b := a
+	b.Inputs = Inputs{
+		Projects: []ProjectSource{{Name: "a", Commit: "3"}, {Name: "c"}},
+		Files:    map[string]string{"methods.csv": "x"},
+	}
+	b.Splits = map[string]int{"trainingSet.csv": 12}
+
+	// when
+	differences := Diff(a, b)
+
+	// then
+	assert.Equal(t, []Difference{
+		{Field: "projects.a", Old: "1", New: "3"},
+		{Field: "projects.b", Old: "2"},
+		{Field: "projects.c", New: "(no commit)"},
+		{Field: "splits.trainingSet.csv", Old: "10", New: "12"},
+	}, differences)
+}
+
+func TestIsStale(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	inputs := Inputs{
+		Files:                    map[string]string{"methods.csv": "x"},
+		DatasetConfigurationHash: HashValues("filter"),
+	}
+	changedInputs := inputs
+	changedInputs.DatasetConfigurationHash = HashValues("other filter")
+
+	// when
+	staleWithoutManifest := IsStale(dir, inputs)
+	err := Manifest{Inputs: inputs}.Save(filepath.Join(dir, FileName))
+
+	// then
+	assert.NoError(t, err)
+	assert.False(t, staleWithoutManifest)
+	assert.False(t, IsStale(dir, inputs))
+	assert.True(t, IsStale(dir, changedInputs))
+}
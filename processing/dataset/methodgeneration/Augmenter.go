@@ -0,0 +1,175 @@
+package methodgeneration
+
+import (
+	"math/rand"
+	"returntypes-langserver/common/code/java"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/utils"
+	"strings"
+	"unicode"
+)
+
+// Groups of interchangeable verbs used if no synonyms are configured
+var DefaultSynonyms = [][]string{
+	{"get", "fetch", "retrieve"},
+	{"create", "make", "build"},
+	{"remove", "delete"},
+	{"find", "search", "lookup"},
+	{"update", "modify"},
+	{"compute", "calculate"},
+	{"check", "verify", "validate"},
+}
+
+// Creates additional training rows by augmenting existing rows as configured in the augmentation options.
+type Augmenter struct {
+	Options  configuration.DatasetAugmentation
+	random   *rand.Rand
+	synonyms map[string][]string
+}
+
+type augmentation func(row csv.MethodGenerationDatasetRow) (csv.MethodGenerationDatasetRow, bool)
+
+func NewAugmenter(options configuration.DatasetAugmentation) *Augmenter {
+	augmenter := &Augmenter{
+		Options:  options,
+		random:   rand.New(rand.NewSource(options.Seed)),
+		synonyms: make(map[string][]string),
+	}
+	groups := options.Synonyms
+	if len(groups) == 0 {
+		groups = DefaultSynonyms
+	}
+	for _, group := range groups {
+		for _, verb := range group {
+			augmenter.synonyms[strings.ToLower(verb)] = group
+		}
+	}
+	return augmenter
+}
+
+func (a *Augmenter) IsActive() bool {
+	return a.Options.IsActive()
+}
+
+// Returns the rows including the augmented rows. Each augmented row follows the row it is created from.
+func (a *Augmenter) Augment(rows []csv.MethodGenerationDatasetRow) []csv.MethodGenerationDatasetRow {
+	if !a.IsActive() {
+		return rows
+	}
+	augmentations := []struct {
+		ratio   float64
+		augment augmentation
+	}{
+		{a.Options.MethodNameSynonyms, a.replaceVerbBySynonym},
+		{a.Options.ShuffledParameters, a.shuffleParameters},
+		{a.Options.DroppedClassContext, dropClassContext},
+	}
+
+	output := make([]csv.MethodGenerationDatasetRow, 0, len(rows))
+	for _, row := range rows {
+		output = append(output, row)
+		for _, augmentation := range augmentations {
+			if augmentation.ratio <= 0 || a.random.Float64() >= augmentation.ratio {
+				continue
+			}
+			if augmented, ok := augmentation.augment(row); ok {
+				output = append(output, augmented)
+			}
+		}
+	}
+	return output
+}
+
+// Replaces the verb at the beginning of the method name by another verb of the same synonym group.
+func (a *Augmenter) replaceVerbBySynonym(row csv.MethodGenerationDatasetRow) (csv.MethodGenerationDatasetRow, bool) {
+	verb, rest := splitVerb(row.MethodName)
+	group, ok := a.synonyms[strings.ToLower(verb)]
+	if !ok {
+		return row, false
+	}
+	candidates := make([]string, 0, len(group)-1)
+	for _, synonym := range group {
+		if !strings.EqualFold(synonym, verb) {
+			candidates = append(candidates, synonym)
+		}
+	}
+	if len(candidates) == 0 {
+		return row, false
+	}
+	row.MethodName = candidates[a.random.Intn(len(candidates))] + rest
+	return row, true
+}
+
+// Splits the method name into the first word (the verb) and the rest starting with an upper case letter.
+func splitVerb(methodName string) (verb, rest string) {
+	for i, r := range methodName {
+		if unicode.IsUpper(r) {
+			return methodName[:i], methodName[i:]
+		}
+	}
+	return methodName, ""
+}
+
+// Shuffles the parameters if their order carries no information which could not be recovered by their types, which
+// is only the case if all types are distinct. A trailing array parameter might be a varargs parameter and is kept in place.
+func (a *Augmenter) shuffleParameters(row csv.MethodGenerationDatasetRow) (csv.MethodGenerationDatasetRow, bool) {
+	if csv.IsEmptyList(row.Parameters) {
+		return row, false
+	}
+	parameters, err := java.ParseParameterList(row.Parameters)
+	if err != nil {
+		return row, false
+	}
+	movable := len(parameters)
+	if movable > 0 && parameters[movable-1].Type.IsArrayType {
+		movable--
+	}
+	if movable < 2 || !hasDistinctTypes(parameters) {
+		return row, false
+	}
+
+	original := make([]java.Parameter, len(parameters))
+	copy(original, parameters)
+	for isSameOrder(original, parameters) {
+		a.random.Shuffle(movable, func(i, j int) {
+			parameters[i], parameters[j] = parameters[j], parameters[i]
+		})
+	}
+	row.Parameters = java.FormatParameterList(parameters, nil)
+	return row, true
+}
+
+func hasDistinctTypes(parameters []java.Parameter) bool {
+	types := make(utils.StringSet)
+	for _, parameter := range parameters {
+		typeName := parameter.Type.TypeName
+		if parameter.Type.IsArrayType {
+			typeName += java.ArrayTypeExtension
+		}
+		if types.Has(typeName) {
+			return false
+		}
+		types.Put(typeName)
+	}
+	return true
+}
+
+func isSameOrder(a, b []java.Parameter) bool {
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes the class name and the context types, so the model has to generate the method only by it's name.
+func dropClassContext(row csv.MethodGenerationDatasetRow) (csv.MethodGenerationDatasetRow, bool) {
+	if row.ClassName == "" && csv.IsEmptyList(row.ContextTypes) {
+		return row, false
+	}
+	row.ClassName = ""
+	row.ContextTypes = nil
+	return row, true
+}
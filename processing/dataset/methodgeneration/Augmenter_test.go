@@ -0,0 +1,70 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAugmentMethodNameSynonyms(t *testing.T) {
+	// given
+	augmenter := NewAugmenter(configuration.DatasetAugmentation{
+		MethodNameSynonyms: 1,
+		Synonyms:           [][]string{{"get", "fetch"}},
+	})
+	rows := []csv.MethodGenerationDatasetRow{
+		{MethodName: "getName", ReturnType: "String"},
+		{MethodName: "setName", ReturnType: "void"},
+	}
+
+	// when
+	augmented := augmenter.Augment(rows)
+
+	// then
+	assert.Len(t, augmented, 3)
+	assert.Equal(t, "getName", augmented[0].MethodName)
+	assert.Equal(t, "fetchName", augmented[1].MethodName)
+	assert.Equal(t, "String", augmented[1].ReturnType)
+	assert.Equal(t, "setName", augmented[2].MethodName)
+}
+
+func TestAugmentShuffledParameters(t *testing.T) {
+	// given
+	augmenter := NewAugmenter(configuration.DatasetAugmentation{
+		ShuffledParameters: 1,
+	})
+	rows := []csv.MethodGenerationDatasetRow{
+		{MethodName: "put", Parameters: []string{"String/key", "int/value", "Object[]/args"}},
+		{MethodName: "add", Parameters: []string{"int/a", "int/b"}},
+	}
+
+	// when
+	augmented := augmenter.Augment(rows)
+
+	// then
+	assert.Len(t, augmented, 3)
+	assert.Equal(t, []string{"int/value", "String/key", "Object[]/args"}, augmented[1].Parameters)
+	assert.Equal(t, "add", augmented[2].MethodName)
+}
+
+func TestAugmentDroppedClassContext(t *testing.T) {
+	// given
+	augmenter := NewAugmenter(configuration.DatasetAugmentation{
+		DroppedClassContext: 1,
+	})
+	rows := []csv.MethodGenerationDatasetRow{
+		{ClassName: "a.Person", MethodName: "getName", ContextTypes: []string{"Person"}},
+	}
+
+	// when
+	augmented := augmenter.Augment(rows)
+
+	// then
+	assert.Len(t, augmented, 2)
+	assert.Equal(t, "a.Person", augmented[0].ClassName)
+	assert.Equal(t, "", augmented[1].ClassName)
+	assert.Nil(t, augmented[1].ContextTypes)
+}
@@ -0,0 +1,127 @@
+package methodgeneration
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+)
+
+const CheckpointsResultOutputFile = "methodgeneration_checkpoints.xlsx"
+
+// The label of the final model in the checkpoint results
+const finalModelLabel = "final"
+
+// The evaluation result of a checkpoint. The checkpoint is empty for the final model.
+type CheckpointResult struct {
+	Checkpoint string
+	Set        *EvaluationSet
+}
+
+// Returns the step of the checkpoint (like 1800 for checkpoint-1800-epoch-1) or -1 for the final model.
+func (r CheckpointResult) Step() int {
+	splitted := strings.Split(r.Checkpoint, "-")
+	if len(splitted) < 2 {
+		return -1
+	}
+	step, err := strconv.Atoi(splitted[1])
+	if err != nil {
+		return -1
+	}
+	return step
+}
+
+func (r CheckpointResult) Label() string {
+	if r.Checkpoint == "" {
+		return finalModelLabel
+	} else if step := r.Step(); step >= 0 {
+		return fmt.Sprint(step)
+	}
+	return r.Checkpoint
+}
+
+// The values of a rater result over all checkpoints.
+type CheckpointScore struct {
+	AggregatedScore
+	// True if the value is the first numeric value of the rater result. A learning curve is drawn for these values.
+	IsMainValue bool
+}
+
+// Returns the index of the checkpoint with the highest value.
+func (s CheckpointScore) Best() int {
+	best := 0
+	for i, value := range s.Values {
+		if value > s.Values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// Aggregates the scores of all checkpoints into one result file. Checkpoints which were evaluated before are rated
+// again from their generated methods files. Checkpoints without generated methods file are left out.
+func (e *Evaluator) writeCheckpointScores(path string, results []CheckpointResult) errors.Error {
+	evaluated := make([]CheckpointResult, 0, len(results))
+	for _, result := range results {
+		if result.Set == nil {
+			set, err := e.rateGeneratedMethods(filepath.Join(path, result.Checkpoint))
+			if err != nil {
+				return err
+			} else if set == nil {
+				log.Info("Skip checkpoint %s in the checkpoint scores as there is no generated methods file\n", result.Label())
+				continue
+			}
+			result.Set = set
+		}
+		evaluated = append(evaluated, result)
+	}
+	if len(evaluated) == 0 {
+		return nil
+	}
+
+	sortCheckpointResults(evaluated)
+	labels := make([]string, len(evaluated))
+	sets := make([]*EvaluationSet, len(evaluated))
+	for i, result := range evaluated {
+		labels[i], sets[i] = result.Label(), result.Set
+	}
+
+	writer, err := NewResultWriter(filepath.Join(path, e.Dataset.Name()+CheckpointsResultOutputFile))
+	if err != nil {
+		return err
+	} else if err := writer.WriteCheckpointScores(labels, AggregateCheckpointScores(sets)); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Sorts the results by their step. The final model is the last one.
+func sortCheckpointResults(results []CheckpointResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		stepI, stepJ := results[i].Step(), results[j].Step()
+		if stepI < 0 || stepJ < 0 {
+			return stepJ < 0 && stepI >= 0
+		}
+		return stepI < stepJ
+	})
+}
+
+// Aggregates the numeric values of the rater results of each checkpoint and marks the first value of each rater.
+func AggregateCheckpointScores(sets []*EvaluationSet) []CheckpointScore {
+	aggregated := AggregateScores(sets)
+	scores := make([]CheckpointScore, len(aggregated))
+	seenRaters := make(map[string]bool)
+	for i, score := range aggregated {
+		key := score.SetName + "\x00" + score.RaterName
+		scores[i] = CheckpointScore{
+			AggregatedScore: score,
+			IsMainValue:     !seenRaters[key],
+		}
+		seenRaters[key] = true
+	}
+	return scores
+}
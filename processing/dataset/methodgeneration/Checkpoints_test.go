@@ -0,0 +1,28 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateCheckpointScores(t *testing.T) {
+	// given
+	results := []CheckpointResult{
+		{Set: &EvaluationSet{Name: "all", Rater: []Metric{&ExactRater{matches: 2, count: 4}}}},
+		{Checkpoint: "checkpoint-1000-epoch-2", Set: &EvaluationSet{Name: "all", Rater: []Metric{&ExactRater{matches: 3, count: 4}}}},
+		{Checkpoint: "checkpoint-500-epoch-1", Set: &EvaluationSet{Name: "all", Rater: []Metric{&ExactRater{matches: 1, count: 4}}}},
+	}
+
+	// when
+	sortCheckpointResults(results)
+	scores := AggregateCheckpointScores([]*EvaluationSet{results[0].Set, results[1].Set, results[2].Set})
+
+	// then
+	assert.Equal(t, []string{"500", "1000", "final"}, []string{results[0].Label(), results[1].Label(), results[2].Label()})
+	assert.Len(t, scores, 3)
+	assert.True(t, scores[0].IsMainValue)
+	assert.False(t, scores[1].IsMainValue)
+	assert.Equal(t, []float64{0.25, 0.75, 0.5}, scores[0].Values)
+	assert.Equal(t, 1, scores[0].Best())
+}
@@ -0,0 +1,198 @@
+package methodgeneration
+
+import (
+	"math/rand"
+	"path/filepath"
+	"strings"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/metrics"
+)
+
+const ComparisonOutputFile = "methodgeneration_comparison.xlsx"
+
+type ComparisonOptions struct {
+	// The number of bootstrap samples and of the trials of the approximate randomization test
+	Samples int
+	// The confidence level of the confidence intervals (e.g. 0.95)
+	Confidence float64
+	Seed       int64
+}
+
+// The comparison of two models on one value of a metric in an evaluation set.
+type MetricComparison struct {
+	SetName   string
+	RaterName string
+	Label     string
+	// The number of methods of the evaluation set which are rated
+	Methods int
+	MeanA   float64
+	MeanB   float64
+	// The paired bootstrap result for the difference of the models (B - A)
+	Bootstrap metrics.BootstrapResult
+	// The p-value of the approximate randomization test
+	RandomizationPValue float64
+}
+
+// Compares the generated methods of two models on the same evaluation set for each metric configured for the evaluation.
+type Comparison struct {
+	options     ComparisonOptions
+	resources   RaterResources
+	comparisons []MetricComparison
+}
+
+func NewComparison(options ComparisonOptions) *Comparison {
+	return &Comparison{options: options}
+}
+
+// Loads the generated methods files (GeneratedMethodsFile) of two models, compares them and writes the comparison sheet
+// to the output path.
+func (c *Comparison) CompareFiles(pathA, pathB, outputPath string) errors.Error {
+	methodsA, err := LoadGeneratedMethods(pathA)
+	if err != nil {
+		return err
+	}
+	methodsB, err := LoadGeneratedMethods(pathB)
+	if err != nil {
+		return err
+	}
+	comparisons, err := c.Compare(methodsA, methodsB)
+	if err != nil {
+		return err
+	}
+
+	writer, err := NewResultWriter(outputPath)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteComparison(modelName(pathA), modelName(pathB), c.options.Confidence, comparisons); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Returns the dataset name of a generated methods file or the name of its directory if the file has no dataset prefix.
+func modelName(path string) string {
+	if name := strings.TrimSuffix(filepath.Base(path), GeneratedMethodsFile); name != "" && name != filepath.Base(path) {
+		return strings.TrimRight(name, "_")
+	}
+	return filepath.Base(filepath.Dir(path))
+}
+
+// Compares the generated methods of two models. methodsA[i] and methodsB[i] have to be generated for the same method.
+func (c *Comparison) Compare(methodsA, methodsB []Method) ([]MetricComparison, errors.Error) {
+	if len(methodsA) != len(methodsB) {
+		return nil, errors.New("Comparison", "Expected the same evaluation set for both models but got %d and %d methods", len(methodsA), len(methodsB))
+	}
+	for i := range methodsA {
+		if methodsA[i].Name != methodsB[i].Name || methodsA[i].ExpectedDefinition.String() != methodsB[i].ExpectedDefinition.String() {
+			return nil, errors.New("Comparison", "Expected the same evaluation set for both models but method %d differs: %s and %s", i+1, methodsA[i].Name, methodsB[i].Name)
+		}
+	}
+
+	c.comparisons = make([]MetricComparison, 0)
+	for _, set := range configuration.EvaluationSubsets() {
+		if err := c.compareSet(set, methodsA, methodsB); err != nil {
+			return nil, err
+		}
+	}
+	return c.comparisons, nil
+}
+
+// Compares the models on each metric of the set and its subsets. The methods are the ones accepted by the parent sets.
+func (c *Comparison) compareSet(setConfiguration configuration.EvaluationSet, methodsA, methodsB []Method) errors.Error {
+	set := EvaluationSet{Name: setConfiguration.Name, Filter: setConfiguration.Filter}
+	acceptedA, acceptedB := make([]Method, 0, len(methodsA)), make([]Method, 0, len(methodsB))
+	for i := range methodsA {
+		if set.IsMethodAccepted(methodsA[i]) {
+			acceptedA = append(acceptedA, methodsA[i])
+			acceptedB = append(acceptedB, methodsB[i])
+		}
+	}
+
+	for _, metric := range setConfiguration.Metrics {
+		scoresA, err := c.methodScores(metric, acceptedA)
+		if err != nil {
+			return err
+		}
+		scoresB, err := c.methodScores(metric, acceptedB)
+		if err != nil {
+			return err
+		}
+		if len(scoresA.values) == 0 || len(scoresA.values) != len(scoresB.values) {
+			continue
+		}
+		random := rand.New(rand.NewSource(c.options.Seed))
+		c.comparisons = append(c.comparisons, MetricComparison{
+			SetName:             set.Name,
+			RaterName:           scoresA.raterName,
+			Label:               scoresA.label,
+			Methods:             len(scoresA.values),
+			MeanA:               meanOf(scoresA.values),
+			MeanB:               meanOf(scoresB.values),
+			Bootstrap:           metrics.PairedBootstrap(scoresA.values, scoresB.values, c.options.Samples, c.options.Confidence, random),
+			RandomizationPValue: metrics.ApproximateRandomization(scoresA.values, scoresB.values, c.options.Samples, random),
+		})
+	}
+
+	for _, subset := range setConfiguration.Subsets {
+		if err := c.compareSet(subset, acceptedA, acceptedB); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The scores of each method rated on its own by a metric.
+type methodScores struct {
+	raterName string
+	// The label of the value in the rater result
+	label  string
+	values []float64
+}
+
+// Rates each method with a new rater of the metric and collects the first numeric value of the results, as the
+// following values are counts or details. The values are empty if the rater has no numeric value for every method.
+func (c *Comparison) methodScores(metric configuration.MetricConfiguration, methods []Method) (methodScores, errors.Error) {
+	scores := methodScores{values: make([]float64, 0, len(methods))}
+	for _, method := range methods {
+		set := EvaluationSet{}
+		if err := set.initRater([]configuration.MetricConfiguration{metric}, &c.resources); err != nil {
+			return scores, err
+		}
+		rater := set.Rater[0]
+		rater.Rate(method)
+		label, value, ok := firstNumericValue(rater.Result())
+		if !ok || scores.label != "" && label != scores.label {
+			return methodScores{}, nil
+		}
+		scores.raterName, scores.label = rater.Name(), label
+		scores.values = append(scores.values, value)
+	}
+	return scores, nil
+}
+
+func firstNumericValue(result [][]interface{}) (string, float64, bool) {
+	for _, row := range result {
+		if len(row) != 2 {
+			continue
+		}
+		label, isLabel := row[0].(string)
+		if value, isNumeric := toFloat(row[1]); isLabel && isNumeric {
+			return label, value, true
+		}
+	}
+	return "", 0, false
+}
+
+func meanOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
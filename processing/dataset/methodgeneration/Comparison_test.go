@@ -0,0 +1,45 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/services/predictor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDefinition(t *testing.T) {
+	// when
+	values := parseDefinition("array list [tsp] names [psp] int [arr] [tsp] ids [rsp] void")
+	withoutParameters := parseDefinition(" [rsp] string")
+
+	// then
+	assert.Equal(t, predictor.MethodValues{
+		ReturnType: "void",
+		Parameters: []predictor.Parameter{
+			{Name: "names", Type: "array list"},
+			{Name: "ids", Type: "int", IsArray: true},
+		},
+	}, values)
+	assert.Equal(t, "string", withoutParameters.ReturnType)
+	assert.Empty(t, withoutParameters.Parameters)
+}
+
+func TestMethodScoresOfComparison(t *testing.T) {
+	// given
+	comparison := NewComparison(ComparisonOptions{Samples: 100, Confidence: 0.95, Seed: 1})
+	methods := []Method{
+		methodWithSuggestions("[rsp] string", "[rsp] string"),
+		methodWithSuggestions("[rsp] int", "[rsp] string"),
+		methodWithSuggestions("[rsp] void", "[rsp] void"),
+	}
+
+	// when
+	scores, err := comparison.methodScores(configuration.MetricConfiguration{"type": configuration.ExactMatch}, methods)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "Average", scores.label)
+	assert.Equal(t, []float64{1, 0, 1}, scores.values)
+}
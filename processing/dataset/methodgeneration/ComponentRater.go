@@ -0,0 +1,264 @@
+package methodgeneration
+
+import (
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/excel"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/services/predictor"
+	"sort"
+	"strings"
+)
+
+const defaultMaxReturnTypeClasses = 10
+
+// The label of the return type classes grouped together in the confusion matrix
+const otherReturnTypeClasses = "other"
+
+// Counts for the precision and recall of one component of the generated methods (micro averaged over all methods).
+type componentScore struct {
+	truePositives float64
+	generated     float64
+	expected      float64
+}
+
+func (s *componentScore) add(truePositives, generated, expected int) {
+	s.truePositives += float64(truePositives)
+	s.generated += float64(generated)
+	s.expected += float64(expected)
+}
+
+func (s componentScore) precision() float64 {
+	if s.generated == 0 {
+		return 0
+	}
+	return s.truePositives / s.generated
+}
+
+func (s componentScore) recall() float64 {
+	if s.expected == 0 {
+		return 0
+	}
+	return s.truePositives / s.expected
+}
+
+func (s componentScore) f1() float64 {
+	if s.precision()+s.recall() == 0 {
+		return 0
+	}
+	return metrics.FScore(s.precision(), s.recall(), 1)
+}
+
+func (s componentScore) rows(name string) [][]interface{} {
+	return [][]interface{}{{name + " precision", s.precision()},
+		{name + " recall", s.recall()},
+		{name + " F1", s.f1()}}
+}
+
+// Rates the return type, the parameter count, the parameter types and the parameter names of the generated methods
+// separately, so it is visible which part of the method definitions the model struggles with.
+type ComponentRater struct {
+	config              configuration.ComponentsConfiguration
+	returnTypeMatches   float64
+	returnTypeTokens    componentScore
+	parameterCountMatch float64
+	parameterCount      componentScore
+	parameterTypes      componentScore
+	parameterNames      componentScore
+	count               float64
+	// The number of methods by their expected and their generated return type class
+	confusion map[string]map[string]int
+}
+
+func NewComponentRater(config configuration.MetricConfiguration) (*ComponentRater, errors.Error) {
+	c, err := config.AsComponents()
+	if err != nil {
+		return nil, err
+	}
+	if c.MaxReturnTypeClasses <= 0 {
+		c.MaxReturnTypeClasses = defaultMaxReturnTypeClasses
+	}
+	return &ComponentRater{
+		config:    c,
+		confusion: make(map[string]map[string]int),
+	}, nil
+}
+
+func (r *ComponentRater) Rate(m Method) {
+	generated, expected := m.Method.Values, m.ExpectedValues
+
+	expectedReturnType, generatedReturnType := normalizeWords(expected.ReturnType), normalizeWords(generated.ReturnType)
+	if expectedReturnType == generatedReturnType {
+		r.returnTypeMatches++
+	}
+	generatedTokens, expectedTokens := strings.Fields(generatedReturnType), strings.Fields(expectedReturnType)
+	r.returnTypeTokens.add(countCommonTokens(generatedTokens, expectedTokens), len(generatedTokens), len(expectedTokens))
+	if r.confusion[expectedReturnType] == nil {
+		r.confusion[expectedReturnType] = make(map[string]int)
+	}
+	r.confusion[expectedReturnType][generatedReturnType]++
+
+	if len(generated.Parameters) == len(expected.Parameters) {
+		r.parameterCountMatch++
+	}
+	r.parameterCount.add(minInt(len(generated.Parameters), len(expected.Parameters)), len(generated.Parameters), len(expected.Parameters))
+	matchingTypes, matchingNames := 0, 0
+	for _, pair := range alignParameters(generated.Parameters, expected.Parameters) {
+		generatedParameter, expectedParameter := generated.Parameters[pair[0]], expected.Parameters[pair[1]]
+		if normalizeWords(generatedParameter.Type) == normalizeWords(expectedParameter.Type) && generatedParameter.IsArray == expectedParameter.IsArray {
+			matchingTypes++
+		}
+		if normalizeWords(generatedParameter.Name) == normalizeWords(expectedParameter.Name) {
+			matchingNames++
+		}
+	}
+	r.parameterTypes.add(matchingTypes, len(generated.Parameters), len(expected.Parameters))
+	r.parameterNames.add(matchingNames, len(generated.Parameters), len(expected.Parameters))
+	r.count++
+}
+
+// Aligns the generated parameters to the expected parameters and returns the pairs of their indices (generated index,
+// expected index). Parameters with the same name are aligned first, the remaining parameters by their position.
+func alignParameters(generated, expected []predictor.Parameter) [][2]int {
+	pairs := make([][2]int, 0, len(expected))
+	isGeneratedAligned, isExpectedAligned := make([]bool, len(generated)), make([]bool, len(expected))
+	for i := range generated {
+		for j := range expected {
+			if !isExpectedAligned[j] && normalizeWords(generated[i].Name) == normalizeWords(expected[j].Name) {
+				pairs = append(pairs, [2]int{i, j})
+				isGeneratedAligned[i], isExpectedAligned[j] = true, true
+				break
+			}
+		}
+	}
+	j := 0
+	for i := range generated {
+		if isGeneratedAligned[i] {
+			continue
+		}
+		for j < len(expected) && isExpectedAligned[j] {
+			j++
+		}
+		if j == len(expected) {
+			break
+		}
+		pairs = append(pairs, [2]int{i, j})
+		isExpectedAligned[j] = true
+	}
+	return pairs
+}
+
+// Returns the number of tokens which are in both lists (each token is counted as often as it is in both lists).
+func countCommonTokens(generated, expected []string) int {
+	counts := make(map[string]int)
+	for _, token := range expected {
+		counts[token]++
+	}
+	common := 0
+	for _, token := range generated {
+		if counts[token] > 0 {
+			counts[token]--
+			common++
+		}
+	}
+	return common
+}
+
+func normalizeWords(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (r *ComponentRater) Result() [][]interface{} {
+	result := [][]interface{}{{"Return type accuracy", r.returnTypeMatches / r.count}}
+	result = append(result, r.returnTypeTokens.rows("Return type token")...)
+	result = append(result, []interface{}{"Parameter count accuracy", r.parameterCountMatch / r.count})
+	result = append(result, r.parameterCount.rows("Parameter count")...)
+	result = append(result, r.parameterTypes.rows("Parameter type")...)
+	result = append(result, r.parameterNames.rows("Parameter name")...)
+	result = append(result, []interface{}{"Overall count", r.count}, []interface{}{})
+	return append(result, r.confusionMatrix()...)
+}
+
+// Returns the confusion matrix of the most frequent expected return type classes. The rows are the expected classes
+// and the columns the generated classes.
+func (r *ComponentRater) confusionMatrix() [][]interface{} {
+	classes := r.mostFrequentReturnTypeClasses()
+	columnOf := make(map[string]int, len(classes))
+	for i, class := range classes {
+		columnOf[class] = i
+	}
+	hasOther := false
+	for expected, generatedCounts := range r.confusion {
+		for generated := range generatedCounts {
+			_, isExpectedShown := columnOf[expected]
+			_, isGeneratedShown := columnOf[generated]
+			hasOther = hasOther || !isExpectedShown || !isGeneratedShown
+		}
+	}
+	if hasOther {
+		columnOf[otherReturnTypeClasses] = len(classes)
+		classes = append(classes, otherReturnTypeClasses)
+	}
+	indexOf := func(class string) int {
+		if index, ok := columnOf[class]; ok {
+			return index
+		}
+		return columnOf[otherReturnTypeClasses]
+	}
+
+	counts := make([][]int, len(classes))
+	for i := range counts {
+		counts[i] = make([]int, len(classes))
+	}
+	for expected, generatedCounts := range r.confusion {
+		for generated, count := range generatedCounts {
+			counts[indexOf(expected)][indexOf(generated)] += count
+		}
+	}
+
+	header := []interface{}{excel.Markdown("**Expected \\ Generated return type**")}
+	for _, class := range classes {
+		header = append(header, class)
+	}
+	matrix := [][]interface{}{header}
+	for i, class := range classes {
+		row := []interface{}{class}
+		for _, count := range counts[i] {
+			row = append(row, count)
+		}
+		matrix = append(matrix, row)
+	}
+	return matrix
+}
+
+func (r *ComponentRater) mostFrequentReturnTypeClasses() []string {
+	totals := make(map[string]int, len(r.confusion))
+	classes := make([]string, 0, len(r.confusion))
+	for expected, generatedCounts := range r.confusion {
+		for _, count := range generatedCounts {
+			totals[expected] += count
+		}
+		classes = append(classes, expected)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		if totals[classes[i]] == totals[classes[j]] {
+			return classes[i] < classes[j]
+		}
+		return totals[classes[i]] > totals[classes[j]]
+	})
+	if len(classes) > r.config.MaxReturnTypeClasses {
+		classes = classes[:r.config.MaxReturnTypeClasses]
+	}
+	return classes
+}
+
+func (r *ComponentRater) Name() string {
+	return "Component scores"
+}
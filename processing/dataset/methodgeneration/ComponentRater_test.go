@@ -0,0 +1,54 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/services/predictor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignParametersByNameAndPosition(t *testing.T) {
+	// given
+	generated := []predictor.Parameter{{Name: "count", Type: "int"}, {Name: "value", Type: "string"}, {Name: "name", Type: "string"}}
+	expected := []predictor.Parameter{{Name: "name", Type: "string"}, {Name: "size", Type: "int"}}
+
+	// when
+	pairs := alignParameters(generated, expected)
+
+	// then
+	assert.Equal(t, [][2]int{{2, 0}, {0, 1}}, pairs)
+}
+
+func TestComponentRater(t *testing.T) {
+	// given
+	rater, err := NewComponentRater(configuration.MetricConfiguration{"type": configuration.Components, "maxReturnTypeClasses": 1})
+	assert.NoError(t, err)
+	method := func(expected, generated predictor.MethodValues) Method {
+		return Method{ExpectedValues: expected, Method: predictor.Method{Values: generated}}
+	}
+
+	// when
+	rater.Rate(method(
+		predictor.MethodValues{ReturnType: "string", Parameters: []predictor.Parameter{{Name: "name", Type: "string"}, {Name: "size", Type: "int"}}},
+		predictor.MethodValues{ReturnType: "string", Parameters: []predictor.Parameter{{Name: "name", Type: "string"}, {Name: "count", Type: "int"}}},
+	))
+	rater.Rate(method(
+		predictor.MethodValues{ReturnType: "string"},
+		predictor.MethodValues{ReturnType: "array list", Parameters: []predictor.Parameter{{Name: "value", Type: "object"}}},
+	))
+
+	// then
+	assert.Equal(t, 1.0, rater.returnTypeMatches)
+	assert.Equal(t, 1.0/3, rater.returnTypeTokens.precision())
+	assert.Equal(t, 0.5, rater.returnTypeTokens.recall())
+	assert.Equal(t, 1.0, rater.parameterCountMatch)
+	assert.Equal(t, 2.0/3, rater.parameterTypes.precision())
+	assert.Equal(t, 1.0, rater.parameterTypes.recall())
+	assert.Equal(t, 1.0/3, rater.parameterNames.precision())
+	assert.Equal(t, 0.5, rater.parameterNames.recall())
+	matrix := rater.confusionMatrix()
+	assert.Equal(t, []interface{}{"string", 1, 1}, matrix[1])
+	assert.Equal(t, []interface{}{"other", 0, 0}, matrix[2])
+}
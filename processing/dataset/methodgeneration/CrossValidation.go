@@ -0,0 +1,151 @@
+package methodgeneration
+
+import (
+	"math"
+	"path/filepath"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+	"strconv"
+	"strings"
+)
+
+const CrossValidationResultOutputFile = "methodgeneration_crossValidationResult.xlsx"
+
+// Contains the values of one score of a rater over all folds.
+type AggregatedScore struct {
+	// The name of the evaluation set
+	SetName string
+	// The name of the rater
+	RaterName string
+	// The label of the value in the rater result
+	Label string
+	// The value of each fold
+	Values []float64
+}
+
+func (s AggregatedScore) Mean() float64 {
+	sum := 0.0
+	for _, value := range s.Values {
+		sum += value
+	}
+	return sum / float64(len(s.Values))
+}
+
+// Returns the sample standard deviation of the values.
+func (s AggregatedScore) StandardDeviation() float64 {
+	if len(s.Values) < 2 {
+		return 0
+	}
+	mean, sum := s.Mean(), 0.0
+	for _, value := range s.Values {
+		sum += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sum / float64(len(s.Values)-1))
+}
+
+// Evaluates the model of each fold on it's evaluation set and writes the aggregated scores of all folds into one result file.
+// Folds which already have a result are not evaluated again, but their generated methods are rated for the aggregation.
+// Folds which cannot be evaluated are reported and left out of the aggregated scores.
+func (e *Evaluator) evaluateFolds(path string) errors.Error {
+	folds := e.Dataset.Folds()
+	sets := make([]*EvaluationSet, 0, len(folds))
+	failedFolds := make([]string, 0)
+	for i, fold := range folds {
+		if set, err := e.evaluateFold(FoldPath(path, i), i, fold); err != nil {
+			log.ReportProblemWithError(err, "Could not evaluate fold %d of dataset %s\n", i+1, e.Dataset.Name())
+			failedFolds = append(failedFolds, strconv.Itoa(i+1))
+		} else if set == nil {
+			log.ReportProblem("Fold %d of dataset %s has a result but no generated methods file, so it is not part of the aggregated scores\n", i+1, e.Dataset.Name())
+			failedFolds = append(failedFolds, strconv.Itoa(i+1))
+		} else {
+			sets = append(sets, set)
+		}
+	}
+	if len(sets) == 0 {
+		return errors.New("Evaluation", "None of the folds of dataset %s could be evaluated", e.Dataset.Name())
+	} else if len(failedFolds) > 0 {
+		log.ReportProblem("The cross validation scores of dataset %s are aggregated without the folds %s\n", e.Dataset.Name(), strings.Join(failedFolds, ", "))
+	}
+
+	writer, err := NewResultWriter(filepath.Join(path, e.Dataset.Name()+CrossValidationResultOutputFile))
+	if err != nil {
+		return err
+	} else if err := writer.WriteCrossValidationScores(AggregateScores(sets)); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Evaluates the fold if there is no result for it yet. Otherwise the generated methods of the previous evaluation are
+// rated, which returns nil if they were not written.
+func (e *Evaluator) evaluateFold(path string, index int, fold configuration.Dataset) (*EvaluationSet, errors.Error) {
+	evaluator := &Evaluator{
+		Dataset: fold,
+		subsets: e.subsets,
+	}
+	if evaluator.isEvaluationResultPresent(path) {
+		log.Info("Skip evaluation of fold %d of dataset %s as it has a result already\n", index+1, e.Dataset.Name())
+		return evaluator.rateGeneratedMethods(path)
+	}
+	log.Info("Evaluate fold %d of dataset %s\n", index+1, e.Dataset.Name())
+	return evaluator.evaluateAndWriteResult(path, "")
+}
+
+// Aggregates the numeric values of the rater results of the evaluation sets. Each evaluation set is expected to be
+// the result of one fold, so they have the same subsets and raters.
+func AggregateScores(sets []*EvaluationSet) []AggregatedScore {
+	scores := make([]AggregatedScore, 0)
+	indexOfKey := make(map[string]int)
+	for _, set := range sets {
+		collectScores(set, func(setName, raterName, label string, value float64) {
+			key := strings.Join([]string{setName, raterName, label}, "\x00")
+			if _, ok := indexOfKey[key]; !ok {
+				indexOfKey[key] = len(scores)
+				scores = append(scores, AggregatedScore{
+					SetName:   setName,
+					RaterName: raterName,
+					Label:     label,
+				})
+			}
+			index := indexOfKey[key]
+			scores[index].Values = append(scores[index].Values, value)
+		})
+	}
+	return scores
+}
+
+// Calls the consumer for each numeric value in the rater results of the set and it's subsets.
+func collectScores(set *EvaluationSet, consumer func(setName, raterName, label string, value float64)) {
+	if set == nil {
+		return
+	}
+	for _, rater := range set.Rater {
+		for _, row := range rater.Result() {
+			if len(row) != 2 {
+				continue
+			}
+			label, isLabel := row[0].(string)
+			if value, isNumeric := toFloat(row[1]); isLabel && isNumeric {
+				consumer(set.Name, rater.Name(), label, value)
+			}
+		}
+	}
+	for i := range set.Subsets {
+		collectScores(&set.Subsets[i], consumer)
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package methodgeneration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/common/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateScores(t *testing.T) {
+	// given
+	sets := []*EvaluationSet{
+		{Name: "all", Rater: []Metric{&ExactRater{matches: 1, count: 4}}},
+		{Name: "all", Rater: []Metric{&ExactRater{matches: 3, count: 4}}},
+	}
+
+	// when
+	scores := AggregateScores(sets)
+
+	// then
+	assert.Len(t, scores, 3)
+	assert.Equal(t, "Average", scores[0].Label)
+	assert.Equal(t, []float64{0.25, 0.75}, scores[0].Values)
+	assert.InDelta(t, 0.5, scores[0].Mean(), 0.0001)
+	assert.InDelta(t, 0.3536, scores[0].StandardDeviation(), 0.0001)
+}
+
+func TestEvaluateFoldsSkipsEvaluatedFoldsAndAggregatesSucceededFolds(t *testing.T) {
+	// given
+	path := t.TempDir()
+	dataset := configuration.Dataset{DatasetBase: configuration.DatasetBase{NameRaw: "set"}}
+	dataset.CreationOptions.CrossValidation = configuration.CrossValidation{Folds: 2}
+	evaluator := &Evaluator{Dataset: dataset}
+	methods := []Method{{
+		Name:                 "get name",
+		ClassName:            "com.example.User",
+		ExpectedDefinition:   metrics.NewSentence("[rsp] string"),
+		GeneratedDefinition:  metrics.NewSentence("[rsp] string"),
+		GeneratedDefinitions: []*metrics.Sentence{metrics.NewSentence("[rsp] string")},
+	}}
+	// The first fold was evaluated before, the second fold has no evaluation set and fails
+	firstFold := dataset.Folds()[0]
+	assert.NoError(t, os.MkdirAll(FoldPath(path, 0), 0777))
+	assert.NoError(t, WriteGeneratedMethods(filepath.Join(FoldPath(path, 0), firstFold.Name()+GeneratedMethodsFile), methods))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(FoldPath(path, 0), firstFold.Name()+ResultOutputFile), []byte{}, 0644))
+
+	// when
+	err := evaluator.evaluateFolds(path)
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, utils.FileExists(filepath.Join(path, dataset.Name()+CrossValidationResultOutputFile)))
+	content, readErr := ioutil.ReadFile(filepath.Join(FoldPath(path, 0), firstFold.Name()+ResultOutputFile))
+	assert.NoError(t, readErr)
+	assert.Empty(t, content)
+}
+
+func TestEvaluateFoldsFailsIfNoFoldSucceeds(t *testing.T) {
+	// given
+	dataset := configuration.Dataset{DatasetBase: configuration.DatasetBase{NameRaw: "set"}}
+	dataset.CreationOptions.CrossValidation = configuration.CrossValidation{Folds: 2}
+	evaluator := &Evaluator{Dataset: dataset}
+
+	// when
+	err := evaluator.evaluateFolds(t.TempDir())
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestOutputsContainFolds(t *testing.T) {
+	// given
+	dataset := configuration.Dataset{DatasetBase: configuration.DatasetBase{NameRaw: "set"}}
+	dataset.CreationOptions.CrossValidation = configuration.CrossValidation{Folds: 2}
+	name, foldName := dataset.Name(), dataset.Folds()[1].Name()
+
+	// when
+	trainingOutputs := TrainingOutputs("out", dataset)
+	evaluationOutputs := EvaluationOutputs("out", dataset)
+
+	// then
+	assert.Len(t, trainingOutputs, 3)
+	assert.Contains(t, trainingOutputs, filepath.Join("out", "fold2", foldName+TrainingConfigFileSuffix))
+	assert.Len(t, evaluationOutputs, 4)
+	assert.Contains(t, evaluationOutputs, filepath.Join("out", name+CrossValidationResultOutputFile))
+	assert.Contains(t, evaluationOutputs, filepath.Join("out", "fold2", foldName+ResultOutputFile))
+}
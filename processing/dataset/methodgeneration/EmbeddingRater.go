@@ -0,0 +1,131 @@
+package methodgeneration
+
+import (
+	"strings"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/metrics"
+)
+
+const defaultEmbeddingSimilarityThreshold = 0.7
+
+// Rates how similar the generated parameter names and type names are to the expected ones by the cosine similarity of
+// their word vectors. In contrast to the exact comparison, synonyms like "size" and "length" are rated as similar.
+// Names consisting of multiple words are represented by the average vector of their words.
+type EmbeddingSimilarityRater struct {
+	embeddings             *metrics.Embeddings
+	threshold              float64
+	parameterNameScore     float64
+	parameterNamesAbove    float64
+	parameterNamesCompared float64
+	typeNameScore          float64
+	typeNamesAbove         float64
+	typeNamesCompared      float64
+	outOfVocabulary        float64
+	count                  float64
+}
+
+func NewEmbeddingSimilarityRater(config configuration.MetricConfiguration, resources *RaterResources) (*EmbeddingSimilarityRater, errors.Error) {
+	c, err := config.AsEmbeddingSimilarity()
+	if err != nil {
+		return nil, err
+	}
+	embeddings, err := resources.Embeddings(c.Path)
+	if err != nil {
+		return nil, err
+	}
+	return newEmbeddingSimilarityRater(c, embeddings), nil
+}
+
+func newEmbeddingSimilarityRater(config configuration.EmbeddingSimilarityConfiguration, embeddings *metrics.Embeddings) *EmbeddingSimilarityRater {
+	if config.Threshold <= 0 {
+		config.Threshold = defaultEmbeddingSimilarityThreshold
+	}
+	return &EmbeddingSimilarityRater{
+		embeddings: embeddings,
+		threshold:  config.Threshold,
+	}
+}
+
+func (r *EmbeddingSimilarityRater) Rate(m Method) {
+	generated, expected := m.Method.Values, m.ExpectedValues
+	pairs := alignParameters(generated.Parameters, expected.Parameters)
+
+	// unaligned parameters (missing or extra ones) have a similarity of 0
+	parameterCount := maxInt(len(generated.Parameters), len(expected.Parameters))
+	if parameterCount == 0 {
+		r.parameterNameScore++
+	} else {
+		sum := 0.0
+		for _, pair := range pairs {
+			similarity := r.similarity(generated.Parameters[pair[0]].Name, expected.Parameters[pair[1]].Name)
+			sum += similarity
+			r.countAboveThreshold(similarity, &r.parameterNamesAbove)
+		}
+		r.parameterNameScore += sum / float64(parameterCount)
+		r.parameterNamesCompared += float64(parameterCount)
+	}
+
+	typeSimilarity := r.similarity(generated.ReturnType, expected.ReturnType)
+	r.countAboveThreshold(typeSimilarity, &r.typeNamesAbove)
+	for _, pair := range pairs {
+		similarity := r.similarity(generated.Parameters[pair[0]].Type, expected.Parameters[pair[1]].Type)
+		typeSimilarity += similarity
+		r.countAboveThreshold(similarity, &r.typeNamesAbove)
+	}
+	r.typeNameScore += typeSimilarity / float64(len(pairs)+1)
+	r.typeNamesCompared += float64(len(pairs) + 1)
+	r.count++
+}
+
+// Returns the similarity of two names between 0 and 1. Equal names have a similarity of 1 even if their words are
+// unknown. Names without any known word have a similarity of 0 and are counted as out of vocabulary.
+func (r *EmbeddingSimilarityRater) similarity(generated, expected string) float64 {
+	if normalizeWords(generated) == normalizeWords(expected) {
+		return 1
+	}
+	similarity, ok := r.embeddings.Similarity(strings.Fields(generated), strings.Fields(expected))
+	if !ok {
+		r.outOfVocabulary++
+		return 0
+	}
+	if similarity < 0 {
+		return 0
+	}
+	return similarity
+}
+
+func (r *EmbeddingSimilarityRater) countAboveThreshold(similarity float64, counter *float64) {
+	if similarity >= r.threshold {
+		*counter++
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (r *EmbeddingSimilarityRater) Result() [][]interface{} {
+	return [][]interface{}{{"Parameter name similarity", r.parameterNameScore / r.count},
+		{"Parameter names above threshold", share(r.parameterNamesAbove, r.parameterNamesCompared)},
+		{"Type name similarity", r.typeNameScore / r.count},
+		{"Type names above threshold", share(r.typeNamesAbove, r.typeNamesCompared)},
+		{"Out of vocabulary names", r.outOfVocabulary},
+		{"Threshold", r.threshold},
+		{"Overall count", r.count}}
+}
+
+func share(value, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return value / total
+}
+
+func (r *EmbeddingSimilarityRater) Name() string {
+	return "Embedding similarity"
+}
@@ -0,0 +1,33 @@
+package methodgeneration
+
+import (
+	"strings"
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/services/predictor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddingSimilarityRater(t *testing.T) {
+	// given
+	embeddings, err := metrics.ReadEmbeddings(strings.NewReader("4 2\nsize 1 0\nlength 0.9 0.1\nname 0 1\nint 1 1\n"))
+	assert.NoError(t, err)
+	rater := newEmbeddingSimilarityRater(configuration.EmbeddingSimilarityConfiguration{Threshold: 0.9}, embeddings)
+
+	// when
+	rater.Rate(Method{
+		ExpectedValues: predictor.MethodValues{ReturnType: "int", Parameters: []predictor.Parameter{{Name: "name", Type: "string"}, {Name: "size", Type: "int"}}},
+		Method:         predictor.Method{Values: predictor.MethodValues{ReturnType: "void", Parameters: []predictor.Parameter{{Name: "name", Type: "string"}, {Name: "length", Type: "int"}}}},
+	})
+
+	// then
+	lengthToSize := metrics.CosineSimilarity([]float64{0.9, 0.1}, []float64{1, 0})
+	assert.InDelta(t, (1+lengthToSize)/2, rater.parameterNameScore, 1e-9)
+	assert.Equal(t, 2.0, rater.parameterNamesAbove)
+	assert.Equal(t, 2.0/3, rater.typeNameScore)
+	assert.Equal(t, 2.0, rater.typeNamesAbove)
+	assert.Equal(t, 1.0, rater.outOfVocabulary)
+}
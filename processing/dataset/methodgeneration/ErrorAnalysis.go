@@ -0,0 +1,261 @@
+package methodgeneration
+
+import (
+	"sort"
+	"strings"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/services/predictor"
+)
+
+type ErrorCategory string
+
+const (
+	WrongReturnType     ErrorCategory = "Wrong return type"
+	MissingParameter    ErrorCategory = "Missing parameter"
+	ExtraParameter      ErrorCategory = "Extra parameter"
+	WrongParameterOrder ErrorCategory = "Wrong parameter order"
+	NamingOnly          ErrorCategory = "Naming only"
+	PlaceholderToken    ErrorCategory = "Placeholder token"
+	OtherError          ErrorCategory = "Other"
+)
+
+var ErrorCategories = []ErrorCategory{WrongReturnType, MissingParameter, ExtraParameter, WrongParameterOrder, NamingOnly, PlaceholderToken, OtherError}
+
+// The maximum number of examples per error category
+const maxErrorExamples = 5
+
+// The maximum number of method name prefixes and labels in the error analysis. The remaining ones are left out.
+const maxErrorGroups = 30
+
+// The group of methods whose labels are not known, as they are not in the extracted methods
+const unknownLabelGroup = "(unknown)"
+
+// The group of methods without labels
+const noLabelGroup = "(no label)"
+
+// Categorizes the generated definitions which do not match the expected definitions. The failures are grouped by the
+// prefix of the method name and by the labels of the method.
+type ErrorAnalysis struct {
+	// The labels of the extracted methods by methodLabelKey. If nil, the failures are not grouped by labels.
+	labels     map[string][]string
+	Categories *ErrorGroup
+	Prefixes   map[string]*ErrorGroup
+	Labels     map[string]*ErrorGroup
+}
+
+type ErrorGroup struct {
+	Name     string
+	Methods  int
+	Failures int
+	// The number of failures by their category. A failure may have multiple categories.
+	Categories map[ErrorCategory]int
+	Examples   map[ErrorCategory][]Method
+}
+
+func newErrorGroup(name string) *ErrorGroup {
+	return &ErrorGroup{
+		Name:       name,
+		Categories: make(map[ErrorCategory]int),
+		Examples:   make(map[ErrorCategory][]Method),
+	}
+}
+
+func (g *ErrorGroup) add(m Method, categories []ErrorCategory) {
+	g.Methods++
+	if len(categories) > 0 {
+		g.Failures++
+	}
+	for _, category := range categories {
+		g.Categories[category]++
+		if len(g.Examples[category]) < maxErrorExamples {
+			g.Examples[category] = append(g.Examples[category], m)
+		}
+	}
+}
+
+// The labels may be nil if the labels of the methods are not known.
+func NewErrorAnalysis(labels map[string][]string) *ErrorAnalysis {
+	return &ErrorAnalysis{
+		labels:     labels,
+		Categories: newErrorGroup("All methods"),
+		Prefixes:   make(map[string]*ErrorGroup),
+		Labels:     make(map[string]*ErrorGroup),
+	}
+}
+
+// Loads the labels of the extracted methods. Returns nil if the methods were not extracted.
+func LoadMethodLabels() (map[string][]string, errors.Error) {
+	if !utils.FileExists(configuration.MethodsWithReturnTypesOutputPath()) {
+		return nil, nil
+	}
+	methods, err := csv.NewFileReader(configuration.MethodsWithReturnTypesOutputPath()).ReadMethodRecords()
+	if err != nil {
+		return nil, err
+	}
+	return methodLabelsOf(methods), nil
+}
+
+// Returns the labels of the methods by methodLabelKey.
+func methodLabelsOf(methods []csv.Method) map[string][]string {
+	labels := make(map[string][]string, len(methods))
+	for _, method := range methods {
+		key := methodLabelKey(method.ClassName, method.MethodName)
+		for _, label := range method.Labels {
+			if label != "" && !utils.ContainsString(labels[key], label) {
+				labels[key] = append(labels[key], label)
+			}
+		}
+		if _, ok := labels[key]; !ok {
+			labels[key] = []string{}
+		}
+	}
+	return labels
+}
+
+// Returns the key of a method for the label lookup. The method name may be formatted as sentence (like "get name").
+// Overloaded methods have the same key, so they share their labels.
+func methodLabelKey(className, methodName string) string {
+	return strings.ToLower(className) + "." + typeNameKey(methodName)
+}
+
+func (a *ErrorAnalysis) Add(m Method) {
+	categories := CategorizeFailure(m)
+	a.Categories.add(m, categories)
+
+	prefix := "(none)"
+	if words := strings.Fields(m.Name); len(words) > 0 {
+		prefix = words[0]
+	}
+	a.group(a.Prefixes, prefix).add(m, categories)
+
+	if a.labels == nil {
+		return
+	}
+	labels, ok := a.labels[methodLabelKey(m.ClassName, m.Name)]
+	if !ok {
+		labels = []string{unknownLabelGroup}
+	} else if len(labels) == 0 {
+		labels = []string{noLabelGroup}
+	}
+	for _, label := range labels {
+		a.group(a.Labels, label).add(m, categories)
+	}
+}
+
+func (a *ErrorAnalysis) group(groups map[string]*ErrorGroup, name string) *ErrorGroup {
+	if _, ok := groups[name]; !ok {
+		groups[name] = newErrorGroup(name)
+	}
+	return groups[name]
+}
+
+// Returns the groups with the most failures in descending order.
+func mostFailingGroups(groups map[string]*ErrorGroup) []*ErrorGroup {
+	sorted := make([]*ErrorGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Failures == sorted[j].Failures {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Failures > sorted[j].Failures
+	})
+	if len(sorted) > maxErrorGroups {
+		sorted = sorted[:maxErrorGroups]
+	}
+	return sorted
+}
+
+// Returns the categories of the differences between the generated and the expected definition or nil if they match.
+func CategorizeFailure(m Method) []ErrorCategory {
+	if isExactMatch(m.GeneratedDefinition, m.ExpectedDefinition) {
+		return nil
+	}
+	generated, expected := m.Method.Values, m.ExpectedValues
+	categories := make([]ErrorCategory, 0, 2)
+	if hasPlaceholderToken(generated) {
+		categories = append(categories, PlaceholderToken)
+	}
+	if normalizeWords(generated.ReturnType) != normalizeWords(expected.ReturnType) {
+		categories = append(categories, WrongReturnType)
+	}
+	if len(generated.Parameters) < len(expected.Parameters) {
+		categories = append(categories, MissingParameter)
+	} else if len(generated.Parameters) > len(expected.Parameters) {
+		categories = append(categories, ExtraParameter)
+	}
+	if isParameterOrderDifferent(generated.Parameters, expected.Parameters) {
+		categories = append(categories, WrongParameterOrder)
+	}
+	if len(categories) == 0 && haveSameParameterTypes(generated.Parameters, expected.Parameters) {
+		categories = append(categories, NamingOnly)
+	}
+	if len(categories) == 0 {
+		categories = append(categories, OtherError)
+	}
+	return categories
+}
+
+// Returns true if the generated values contain empty tokens, which are shown as placeholders in method definitions.
+func hasPlaceholderToken(values predictor.MethodValues) bool {
+	if strings.TrimSpace(values.ReturnType) == "" || strings.Contains(values.ReturnType, EmptyTokenPlaceholder) {
+		return true
+	}
+	for _, par := range values.Parameters {
+		if strings.TrimSpace(par.Name) == "" || strings.TrimSpace(par.Type) == "" ||
+			strings.Contains(par.Name, EmptyTokenPlaceholder) || strings.Contains(par.Type, EmptyTokenPlaceholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the parameters which are in both lists (identified by their name) are in a different order.
+func isParameterOrderDifferent(generated, expected []predictor.Parameter) bool {
+	generatedNames, expectedNames := parameterNames(generated), parameterNames(expected)
+	commonGenerated, commonExpected := make([]string, 0), make([]string, 0)
+	for _, name := range generatedNames {
+		if utils.ContainsString(expectedNames, name) {
+			commonGenerated = append(commonGenerated, name)
+		}
+	}
+	for _, name := range expectedNames {
+		if utils.ContainsString(generatedNames, name) {
+			commonExpected = append(commonExpected, name)
+		}
+	}
+	if len(commonGenerated) != len(commonExpected) {
+		return false
+	}
+	for i := range commonGenerated {
+		if commonGenerated[i] != commonExpected[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func parameterNames(parameters []predictor.Parameter) []string {
+	names := make([]string, len(parameters))
+	for i, par := range parameters {
+		names[i] = normalizeWords(par.Name)
+	}
+	return names
+}
+
+func haveSameParameterTypes(generated, expected []predictor.Parameter) bool {
+	if len(generated) != len(expected) {
+		return false
+	}
+	for i := range generated {
+		if normalizeWords(generated[i].Type) != normalizeWords(expected[i].Type) || generated[i].IsArray != expected[i].IsArray {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/services/predictor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func methodWithDefinitions(name, expected, generated string) Method {
+	return Method{
+		Name:                name,
+		ClassName:           "com.example.Person",
+		ExpectedDefinition:  metrics.NewSentence(expected),
+		GeneratedDefinition: metrics.NewSentence(generated),
+		ExpectedValues:      parseDefinition(expected),
+		Method:              predictor.Method{Values: parseDefinition(generated)},
+	}
+}
+
+func TestCategorizeFailure(t *testing.T) {
+	assert.Nil(t, CategorizeFailure(methodWithDefinitions("get name", "[rsp] string", "[rsp] string")))
+	assert.Equal(t, []ErrorCategory{WrongReturnType}, CategorizeFailure(methodWithDefinitions("get name", "[rsp] string", "[rsp] int")))
+	assert.Equal(t, []ErrorCategory{MissingParameter},
+		CategorizeFailure(methodWithDefinitions("set name", "string [tsp] name [psp] int [tsp] index [rsp] void", "string [tsp] name [rsp] void")))
+	assert.Equal(t, []ErrorCategory{ExtraParameter},
+		CategorizeFailure(methodWithDefinitions("set name", "string [tsp] name [rsp] void", "string [tsp] name [psp] int [tsp] index [rsp] void")))
+	assert.Equal(t, []ErrorCategory{WrongParameterOrder},
+		CategorizeFailure(methodWithDefinitions("set name", "string [tsp] name [psp] int [tsp] index [rsp] void", "int [tsp] index [psp] string [tsp] name [rsp] void")))
+	assert.Equal(t, []ErrorCategory{NamingOnly},
+		CategorizeFailure(methodWithDefinitions("set name", "string [tsp] name [rsp] void", "string [tsp] value [rsp] void")))
+	assert.Equal(t, []ErrorCategory{PlaceholderToken, WrongReturnType}, CategorizeFailure(methodWithDefinitions("get name", "[rsp] string", "[rsp]")))
+	assert.Equal(t, []ErrorCategory{OtherError},
+		CategorizeFailure(methodWithDefinitions("set name", "string [tsp] name [rsp] void", "int [tsp] name [rsp] void")))
+}
+
+func TestErrorAnalysisGroups(t *testing.T) {
+	// given
+	analysis := NewErrorAnalysis(map[string][]string{
+		"com.example.person.getname": {"getter"},
+		"com.example.person.isadult": {},
+	})
+
+	// when
+	analysis.Add(methodWithDefinitions("get name", "[rsp] string", "[rsp] int"))
+	analysis.Add(methodWithDefinitions("get name", "[rsp] string", "[rsp] string"))
+	analysis.Add(methodWithDefinitions("is adult", "[rsp] boolean", "[rsp] string"))
+	analysis.Add(methodWithDefinitions("create person", "[rsp] person", "[rsp] string"))
+
+	// then
+	assert.Equal(t, 4, analysis.Categories.Methods)
+	assert.Equal(t, 3, analysis.Categories.Failures)
+	assert.Equal(t, 3, analysis.Categories.Categories[WrongReturnType])
+	assert.Equal(t, 2, analysis.Prefixes["get"].Methods)
+	assert.Equal(t, 1, analysis.Prefixes["get"].Failures)
+	assert.Equal(t, 2, analysis.Labels["getter"].Methods)
+	assert.Equal(t, 1, analysis.Labels[noLabelGroup].Failures)
+	assert.Equal(t, 1, analysis.Labels[unknownLabelGroup].Failures)
+	groups := mostFailingGroups(analysis.Prefixes)
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "create", groups[0].Name)
+}
@@ -1,6 +1,7 @@
 package methodgeneration
 
 import (
+	"fmt"
 	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/common/dataformat/excel"
 	"returntypes-langserver/common/debug/errors"
@@ -13,6 +14,8 @@ type EvaluationResultWriter struct {
 	path        string
 	file        *excelize.File
 	headerStyle *excel.Style
+	// The style of the best values (like the best checkpoint of a metric)
+	highlightStyle *excel.Style
 }
 
 func NewResultWriter(path string) (*EvaluationResultWriter, errors.Error) {
@@ -35,6 +38,14 @@ func (w *EvaluationResultWriter) initStyles() errors.Error {
 		return err
 	}
 	w.headerStyle = &HeaderStyle
+	HighlightStyle := excel.Style{
+		Bold:            true,
+		BackgroundColor: "#C6EFCE",
+	}
+	if _, err := HighlightStyle.ToExcelStyle(w.file); err != nil {
+		return err
+	}
+	w.highlightStyle = &HighlightStyle
 	return nil
 }
 
@@ -140,6 +151,239 @@ func (w *EvaluationResultWriter) writeScoreSheetForSet(evalset *EvaluationSet) e
 	return nil
 }
 
+// Writes the aggregated scores of a cross validation with the mean, standard deviation and the value of each fold.
+func (w *EvaluationResultWriter) WriteCrossValidationScores(scores []AggregatedScore) errors.Error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	sheet := "Cross validation"
+	w.file.NewSheet(sheet)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(0), excel.GetColumnIdentifier(2), 30)
+	cursor := excel.NewCursor(w.file, sheet)
+
+	header := []interface{}{"Set", "Rating method", "Value", "Mean", "Standard deviation"}
+	if len(scores) > 0 {
+		for i := range scores[0].Values {
+			header = append(header, fmt.Sprintf("Fold %d", i+1))
+		}
+	}
+	cursor.SetStyle(w.headerStyle.Id())
+	cursor.WriteRowValues(header...)
+	cursor.SetStyle(0)
+	cursor.Move(0, 1)
+	for _, score := range scores {
+		row := []interface{}{score.SetName, score.RaterName, score.Label, score.Mean(), score.StandardDeviation()}
+		for _, value := range score.Values {
+			row = append(row, value)
+		}
+		cursor.WriteRowValues(row...)
+		cursor.Move(0, 1)
+	}
+	return cursor.Error()
+}
+
+// Writes the comparison of two models with the confidence interval of the difference (B - A) and the p-values of the
+// significance tests.
+func (w *EvaluationResultWriter) WriteComparison(nameA, nameB string, confidence float64, comparisons []MetricComparison) errors.Error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	sheet := "Comparison"
+	w.file.NewSheet(sheet)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(0), excel.GetColumnIdentifier(2), 30)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(3), excel.GetColumnIdentifier(10), 18)
+	cursor := excel.NewCursor(w.file, sheet)
+
+	cursor.SetStyle(w.headerStyle.Id())
+	cursor.WriteRowValues("Model A:", nameA)
+	cursor.Move(0, 1)
+	cursor.WriteRowValues("Model B:", nameB)
+	cursor.Move(0, 2)
+	cursor.WriteRowValues("Set", "Rating method", "Value", "Methods", "A", "B", "Difference (B - A)",
+		fmt.Sprintf("CI %.0f%% lower bound", confidence*100), fmt.Sprintf("CI %.0f%% upper bound", confidence*100),
+		"Bootstrap p-value", "Randomization p-value")
+	cursor.SetStyle(0)
+	cursor.Move(0, 1)
+	for _, comparison := range comparisons {
+		cursor.WriteRowValues(comparison.SetName, comparison.RaterName, comparison.Label, comparison.Methods,
+			comparison.MeanA, comparison.MeanB, comparison.Bootstrap.Difference, comparison.Bootstrap.LowerBound,
+			comparison.Bootstrap.UpperBound, comparison.Bootstrap.PValue, comparison.RandomizationPValue)
+		cursor.Move(0, 1)
+	}
+	return cursor.Error()
+}
+
+// Writes the values of each rater result over the checkpoints. The best checkpoint of the first value of each rater is
+// highlighted and a learning curve is drawn for these values. The labels are the names of the checkpoints in the order of
+// the score values.
+func (w *EvaluationResultWriter) WriteCheckpointScores(labels []string, scores []CheckpointScore) errors.Error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	sheet := "Checkpoints"
+	w.file.NewSheet(sheet)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(0), excel.GetColumnIdentifier(2), 30)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(3), excel.GetColumnIdentifier(4), 15)
+	cursor := excel.NewCursor(w.file, sheet)
+
+	header := []interface{}{"Set", "Rating method", "Value", "Best checkpoint", "Best value"}
+	for _, label := range labels {
+		header = append(header, label)
+	}
+	cursor.SetStyle(w.headerStyle.Id())
+	cursor.WriteRowValues(header...)
+	cursor.SetStyle(0)
+	cursor.Move(0, 1)
+	for _, score := range scores {
+		row := []interface{}{score.SetName, score.RaterName, score.Label, "", ""}
+		if score.IsMainValue && len(score.Values) > 0 {
+			row[3], row[4] = labels[score.Best()], score.Values[score.Best()]
+		}
+		for _, value := range score.Values {
+			row = append(row, value)
+		}
+		cursor.WriteRowValues(row...)
+		if score.IsMainValue && len(score.Values) > 0 {
+			cursor.Move(len(header)-len(labels)+score.Best(), 0)
+			cursor.SetStyle(w.highlightStyle.Id())
+			cursor.ApplyStyle(0, 0)
+			cursor.SetStyle(0)
+			cursor.SetPosition(0, cursor.Y())
+		}
+		cursor.Move(0, 1)
+	}
+	if err := cursor.Error(); err != nil {
+		return err
+	}
+	return w.writeLearningCurves(labels, scores)
+}
+
+// Writes a line chart for each main value of the checkpoint scores.
+func (w *EvaluationResultWriter) writeLearningCurves(labels []string, scores []CheckpointScore) errors.Error {
+	sheet := "Learning curves"
+	w.file.NewSheet(sheet)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(0), excel.GetColumnIdentifier(0), 30)
+	cursor := excel.NewCursor(w.file, sheet)
+
+	categories := make([]interface{}, len(labels))
+	for i, label := range labels {
+		categories[i] = label
+	}
+	for _, score := range scores {
+		if !score.IsMainValue || len(score.Values) == 0 {
+			continue
+		}
+		title := fmt.Sprintf("%s - %s: %s", score.SetName, score.RaterName, score.Label)
+		cursor.SetStyle(w.headerStyle.Id())
+		cursor.WriteRowValues(title, "Best checkpoint:", labels[score.Best()], score.Values[score.Best()])
+		cursor.SetStyle(0)
+		cursor.Move(0, 1)
+
+		values := make([]interface{}, len(score.Values))
+		for i, value := range score.Values {
+			values[i] = value
+		}
+		cursor.WriteChart(excel.Chart{
+			ChartBase: excel.ChartBase{
+				Type: "line",
+				Title: &excel.Title{
+					Name: title,
+				},
+				Format: &excel.Format{
+					XScale:   1.0,
+					YScale:   1.0,
+					XOffset:  15,
+					YOffset:  10,
+					PrintObj: true,
+				},
+				Legend: &excel.Legend{
+					Position: "none",
+				},
+			},
+			Series: []excel.Series{{
+				Name:       score.Label,
+				Categories: categories,
+				Values:     values,
+			}},
+		})
+		cursor.Move(0, 1)
+	}
+	return cursor.Error()
+}
+
+// Writes the error categories of the failed methods with examples and the failures grouped by the method name prefix
+// and by the labels of the methods.
+func (w *EvaluationResultWriter) WriteErrorAnalysis(analysis *ErrorAnalysis) errors.Error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	sheet := "Error analysis"
+	w.file.NewSheet(sheet)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(0), excel.GetColumnIdentifier(0), 25)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(1), excel.GetColumnIdentifier(1), 30)
+	w.file.SetColWidth(sheet, excel.GetColumnIdentifier(2), excel.GetColumnIdentifier(3), 60)
+	cursor := excel.NewCursor(w.file, sheet)
+
+	all := analysis.Categories
+	cursor.SetStyle(w.headerStyle.Id())
+	cursor.WriteRowValues("Error category", "Failures", "Share of failures")
+	cursor.SetStyle(0)
+	cursor.Move(0, 1)
+	for _, category := range ErrorCategories {
+		share := 0.0
+		if all.Failures > 0 {
+			share = float64(all.Categories[category]) / float64(all.Failures)
+		}
+		cursor.WriteRowValues(string(category), all.Categories[category], share)
+		cursor.Move(0, 1)
+	}
+	cursor.WriteRowValues("Failed methods", all.Failures)
+	cursor.Move(0, 1)
+	cursor.WriteRowValues("Overall count", all.Methods)
+	cursor.Move(0, 2)
+
+	w.writeErrorGroups(cursor, "Method name prefix", mostFailingGroups(analysis.Prefixes))
+	if len(analysis.Labels) > 0 {
+		w.writeErrorGroups(cursor, "Label", mostFailingGroups(analysis.Labels))
+	}
+
+	cursor.SetStyle(w.headerStyle.Id())
+	cursor.WriteRowValues("Error category", "Method name", "Expected definition", "Generated definition")
+	cursor.SetStyle(0)
+	cursor.Move(0, 1)
+	for _, category := range ErrorCategories {
+		for _, method := range all.Examples[category] {
+			cursor.WriteRowValues(string(category), method.Name, method.ExpectedDefinition.String(), method.GeneratedDefinition.String())
+			cursor.Move(0, 1)
+		}
+	}
+	return cursor.Error()
+}
+
+func (w *EvaluationResultWriter) writeErrorGroups(cursor *excel.Cursor, groupName string, groups []*ErrorGroup) {
+	header := []interface{}{groupName, "Methods", "Failures", "Failure rate"}
+	for _, category := range ErrorCategories {
+		header = append(header, string(category))
+	}
+	cursor.SetStyle(w.headerStyle.Id())
+	cursor.WriteRowValues(header...)
+	cursor.SetStyle(0)
+	cursor.Move(0, 1)
+	for _, group := range groups {
+		row := []interface{}{group.Name, group.Methods, group.Failures, float64(group.Failures) / float64(group.Methods)}
+		for _, category := range ErrorCategories {
+			row = append(row, group.Categories[category])
+		}
+		cursor.WriteRowValues(row...)
+		cursor.Move(0, 1)
+	}
+	cursor.Move(0, 1)
+}
+
 func (w *EvaluationResultWriter) check() errors.Error {
 	if w.file == nil {
 		return errors.New("Evaluation", "The excel output file does not exist")
@@ -29,7 +29,7 @@ func (e *EvaluationSet) AddMethod(m Method) {
 	}
 }
 
-func (e *EvaluationSet) initRater(metrics []configuration.MetricConfiguration) errors.Error {
+func (e *EvaluationSet) initRater(metrics []configuration.MetricConfiguration, resources *RaterResources) errors.Error {
 	e.Rater = make([]Metric, 0, len(metrics))
 	for _, metric := range metrics {
 		switch metric.Type() {
@@ -77,6 +77,52 @@ func (e *EvaluationSet) initRater(metrics []configuration.MetricConfiguration) e
 				return ErrCouldNotInitialize.Wrap(err)
 			}
 			e.Rater = append(e.Rater, &CompilabilityRater{})
+		case configuration.TypeResolution:
+			resolver, err := resources.TypeResolver()
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			rater, err := NewTypeResolutionRater(metric, resolver)
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			e.Rater = append(e.Rater, rater)
+		case configuration.ExactMatchAtK:
+			rater, err := NewExactMatchAtKRater(metric)
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			e.Rater = append(e.Rater, rater)
+		case configuration.MeanReciprocalRank:
+			rater, err := NewMeanReciprocalRankRater(metric)
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			e.Rater = append(e.Rater, rater)
+		case configuration.BestOfK:
+			rater, err := NewBestOfKRater(metric)
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			e.Rater = append(e.Rater, rater)
+		case configuration.Diversity:
+			rater, err := NewDiversityRater(metric)
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			e.Rater = append(e.Rater, rater)
+		case configuration.Components:
+			rater, err := NewComponentRater(metric)
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			e.Rater = append(e.Rater, rater)
+		case configuration.EmbeddingSimilarity:
+			rater, err := NewEmbeddingSimilarityRater(metric, resources)
+			if err != nil {
+				return ErrCouldNotInitialize.Wrap(err)
+			}
+			e.Rater = append(e.Rater, rater)
 		default:
 			return ErrCouldNotInitialize.Wrap(errors.New("Evaluation", "Unknown metric: %s", metric))
 		}
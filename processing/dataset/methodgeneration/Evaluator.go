@@ -22,13 +22,28 @@ const ResultOutputFile = "methodgeneration_result.xlsx"
 type Evaluator struct {
 	Dataset      configuration.Dataset
 	resultWriter *EvaluationResultWriter
+	report       *HtmlReport
+	resources    RaterResources
+	// The evaluation sets which are used instead of the configured ones if not nil
+	subsets []configuration.EvaluationSet
+	// The labels of the extracted methods, which are loaded for the error analysis
+	methodLabels       map[string][]string
+	methodLabelsLoaded bool
 }
 
 type Method struct {
 	Name                string
 	ExpectedDefinition  *metrics.Sentence
 	GeneratedDefinition *metrics.Sentence
-	Method              predictor.Method
+	// All generated suggestions in the order of their rank (the first one is the generated definition)
+	GeneratedDefinitions []*metrics.Sentence
+	Method               predictor.Method
+	// The expected values formatted like the generated values
+	ExpectedValues predictor.MethodValues
+	// The qualified class name and the context types of the method as they are in the evaluation set (the context of
+	// the method is formatted for the predictor)
+	ClassName    string
+	ContextTypes []string
 }
 
 func NewEvaluator(dataset configuration.Dataset) base.Evaluator {
@@ -39,7 +54,8 @@ func NewEvaluator(dataset configuration.Dataset) base.Evaluator {
 
 func (e *Evaluator) Evaluate(path string) errors.Error {
 	log.Info("Evaluate dataset %s\n", e.Dataset.Name())
-	if err := e.evaluateCheckpoint(path, ""); err != nil {
+	finalSet, err := e.evaluateCheckpoint(path, "")
+	if err != nil {
 		return err
 	}
 	if e.isCheckpointEvaluationActive() {
@@ -49,14 +65,24 @@ func (e *Evaluator) Evaluate(path string) errors.Error {
 			return err
 		}
 		checkpoints = e.reduceCheckpoints(checkpoints)
+		results := make([]CheckpointResult, 0, len(checkpoints)+1)
 		for _, checkpoint := range checkpoints {
 			if e.Dataset.EvaluateOn == configuration.Step || strings.Contains(checkpoint, "epoch") {
 				log.Info("Evaluate checkpoint: %s\n", checkpoint)
-				if err := e.evaluateCheckpoint(path, checkpoint); err != nil {
+				set, err := e.evaluateCheckpoint(path, checkpoint)
+				if err != nil {
 					return err
 				}
+				results = append(results, CheckpointResult{Checkpoint: checkpoint, Set: set})
 			}
 		}
+		results = append(results, CheckpointResult{Set: finalSet})
+		if err := e.writeCheckpointScores(path, results); err != nil {
+			return err
+		}
+	}
+	if e.Dataset.CreationOptions.CrossValidation.IsActive() {
+		return e.evaluateFolds(path)
 	}
 	return nil
 }
@@ -89,37 +115,97 @@ func (e *Evaluator) isCheckpointEvaluationActive() bool {
 	return e.Dataset.EvaluateOn == configuration.Epoch || e.Dataset.EvaluateOn == configuration.Step
 }
 
-func (e *Evaluator) evaluateCheckpoint(path, checkpoint string) errors.Error {
+// Evaluates the checkpoint if there is no result for it yet. Returns the evaluation set containing the scores or nil if
+// the checkpoint was evaluated before.
+func (e *Evaluator) evaluateCheckpoint(path, checkpoint string) (*EvaluationSet, errors.Error) {
 	checkpointPath := path
 	if checkpoint != "" {
 		checkpointPath = filepath.Join(path, checkpoint)
 	}
 	if e.isEvaluationResultPresent(checkpointPath) {
-		return nil
+		return nil, nil
 	}
-	if writer, err := NewResultWriter(filepath.Join(checkpointPath, e.Dataset.Name()+ResultOutputFile)); err != nil {
-		return err
+	return e.evaluateAndWriteResult(path, checkpoint)
+}
+
+// Rates the generated methods file of a previous evaluation. Returns nil if the file does not exist.
+func (e *Evaluator) rateGeneratedMethods(checkpointPath string) (*EvaluationSet, errors.Error) {
+	generatedMethodsPath := filepath.Join(checkpointPath, e.Dataset.Name()+GeneratedMethodsFile)
+	if !utils.FileExists(generatedMethodsPath) {
+		return nil, nil
+	}
+	methods, err := LoadGeneratedMethods(generatedMethodsPath)
+	if err != nil {
+		return nil, err
+	}
+	evalset, err := e.getEvaluationSetConfig()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range methods {
+		evalset.AddMethod(m)
+	}
+	return evalset, nil
+}
+
+// Evaluates the model on the evaluation set and writes the result file. Returns the evaluation set containing the scores.
+func (e *Evaluator) evaluateAndWriteResult(path, checkpoint string) (*EvaluationSet, errors.Error) {
+	methods, err := e.getGeneratedMethodsForEvaluationSet(path, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	checkpointPath := path
+	if checkpoint != "" {
+		checkpointPath = filepath.Join(path, checkpoint)
+	}
+	return e.writeResult(checkpointPath, checkpoint, methods)
+}
+
+// Rates the generated methods and writes the result file, the generated methods file and the report to the output
+// path. Returns the evaluation set containing the scores.
+func (e *Evaluator) writeResult(outputPath, checkpoint string, methods []Method) (*EvaluationSet, errors.Error) {
+	if err := WriteGeneratedMethods(filepath.Join(outputPath, e.Dataset.Name()+GeneratedMethodsFile), methods); err != nil {
+		return nil, err
+	}
+	return e.rateAndWriteResult(outputPath, checkpoint, 1, methods, true)
+}
+
+// Rates the generated methods and writes the result file and the report with the given version to the output path.
+// The outputs of the examples are only generated if withExamples is true, as this requires the predictor. Returns the
+// evaluation set containing the scores.
+func (e *Evaluator) rateAndWriteResult(outputPath, checkpoint string, version int, methods []Method, withExamples bool) (*EvaluationSet, errors.Error) {
+	if writer, err := NewResultWriter(filepath.Join(outputPath, versionedFileName(e.Dataset.Name()+ResultOutputFile, version))); err != nil {
+		return nil, err
 	} else {
 		e.resultWriter = writer
 	}
+	e.report = NewHtmlReport(e.Dataset.Name())
+	if checkpoint != "" {
+		e.report.Title += " - " + checkpoint
+	}
 	evalset, err := e.getEvaluationSetConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := e.evaluateMethods(path, checkpoint, evalset); err != nil {
-		return err
+	if err := e.evaluateMethods(methods, evalset); err != nil {
+		return nil, err
 	}
-	if err := e.writeScoreOutput(checkpointPath, evalset); err != nil {
-		return err
+	if err := e.writeScoreOutput(outputPath, evalset); err != nil {
+		return nil, err
 	}
-	if err := e.writeExampleOutput(checkpointPath, checkpoint, evalset); err != nil {
-		return err
+	if withExamples {
+		if err := e.writeExampleOutput(outputPath, checkpoint, evalset); err != nil {
+			return nil, err
+		}
 	}
 	if err := e.resultWriter.Close(); err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	if err := e.report.Write(filepath.Join(outputPath, versionedFileName(e.Dataset.Name()+ReportOutputFile, version))); err != nil {
+		return nil, err
+	}
+	return evalset, nil
 }
 
 func (e *Evaluator) loadEvaluationSet(path string) ([]predictor.Method, errors.Error) {
@@ -140,8 +226,10 @@ func (e *Evaluator) loadEvaluationSet(path string) ([]predictor.Method, errors.E
 
 func (e *Evaluator) generateMethodDefinitions(methods []predictor.Method, checkpoint string) ([]Method, errors.Error) {
 	contexts := make([]predictor.MethodContext, len(methods))
+	classNames := make([]string, len(methods))
 	for i, method := range methods {
 		contexts[i] = method.Context
+		classNames[i] = strings.Join(method.Context.ClassName, ".")
 	}
 
 	predicted, err := predictor.OnCheckpoint(e.Dataset, checkpoint).GenerateMethods(contexts)
@@ -156,6 +244,12 @@ func (e *Evaluator) generateMethodDefinitions(methods []predictor.Method, checkp
 			Context: contexts[i],
 			Values:  predicted[i][0],
 		}, methods[i].Values)
+		outputMethods[i].GeneratedDefinitions = make([]*metrics.Sentence, len(predicted[i]))
+		for j := range predicted[i] {
+			outputMethods[i].GeneratedDefinitions[j] = e.joinParameters(predicted[i][j])
+		}
+		outputMethods[i].ClassName = classNames[i]
+		outputMethods[i].ContextTypes = methods[i].Context.Types
 	}
 	return outputMethods, err
 }
@@ -175,6 +269,7 @@ func (e *Evaluator) parseOutputToMethod(method predictor.Method, expectedValues
 		Name:                string(method.Context.MethodName),
 		ExpectedDefinition:  e.joinParameters(expectedValues),
 		GeneratedDefinition: e.joinParameters(method.Values),
+		ExpectedValues:      e.formatValues(expectedValues),
 		Method:              method,
 	}
 }
@@ -193,13 +288,30 @@ func (e *Evaluator) joinParameters(values predictor.MethodValues) *metrics.Sente
 	return metrics.NewSentence(str)
 }
 
+func (e *Evaluator) formatValues(values predictor.MethodValues) predictor.MethodValues {
+	formatted := predictor.MethodValues{
+		ReturnType: e.formatString(values.ReturnType),
+		Parameters: make([]predictor.Parameter, len(values.Parameters)),
+	}
+	for i, par := range values.Parameters {
+		par.Name = e.formatString(par.Name)
+		par.Type = e.formatString(par.Type)
+		formatted.Parameters[i] = par
+	}
+	return formatted
+}
+
 func (e *Evaluator) formatString(str string) string {
 	return strings.ToLower(predictor.SplitMethodNameToSentence(str))
 }
 
 func (e *Evaluator) getEvaluationSetConfig() (*EvaluationSet, errors.Error) {
+	subsets := e.subsets
+	if subsets == nil {
+		subsets = configuration.EvaluationSubsets()
+	}
 	set, err := e.buildEvaluationSet(configuration.EvaluationSet{
-		Subsets: configuration.EvaluationSubsets(),
+		Subsets: subsets,
 	})
 	return &set, err
 }
@@ -211,7 +323,7 @@ func (e *Evaluator) buildEvaluationSet(setConfiguration configuration.Evaluation
 		Name:     setConfiguration.Name,
 		Examples: setConfiguration.Examples,
 	}
-	if err := set.initRater(setConfiguration.Metrics); err != nil {
+	if err := set.initRater(setConfiguration.Metrics, &e.resources); err != nil {
 		return set, err
 	}
 
@@ -225,22 +337,48 @@ func (e *Evaluator) buildEvaluationSet(setConfiguration configuration.Evaluation
 	return set, nil
 }
 
-func (e *Evaluator) isEvaluationResultPresent(path string) bool {
-	return utils.FileExists(filepath.Join(path, e.Dataset.Name()+ResultOutputFile))
+// Returns the labels of the extracted methods, which are loaded on first use. Returns nil if the methods were not extracted.
+func (e *Evaluator) getMethodLabels() (map[string][]string, errors.Error) {
+	if !e.methodLabelsLoaded {
+		labels, err := LoadMethodLabels()
+		if err != nil {
+			return nil, err
+		}
+		e.methodLabels, e.methodLabelsLoaded = labels, true
+	}
+	return e.methodLabels, nil
 }
 
-func (e *Evaluator) evaluateMethods(path, checkpoint string, evalset *EvaluationSet) errors.Error {
-	methods, err := e.getGeneratedMethodsForEvaluationSet(path, checkpoint)
-	if err != nil {
-		return err
+// Returns the result files written by the evaluation of the dataset in the path. The results of the checkpoints are left
+// out, as they depend on the checkpoints saved by the predictor.
+func EvaluationOutputs(path string, dataset configuration.Dataset) []string {
+	outputs := []string{filepath.Join(path, dataset.Name()+ResultOutputFile)}
+	if dataset.CreationOptions.CrossValidation.IsActive() {
+		outputs = append(outputs, filepath.Join(path, dataset.Name()+CrossValidationResultOutputFile))
+		for i, fold := range dataset.Folds() {
+			outputs = append(outputs, EvaluationOutputs(FoldPath(path, i), fold)...)
+		}
 	}
+	return outputs
+}
 
+func (e *Evaluator) isEvaluationResultPresent(path string) bool {
+	return utils.FileExists(filepath.Join(path, e.Dataset.Name()+ResultOutputFile))
+}
+
+func (e *Evaluator) evaluateMethods(methods []Method, evalset *EvaluationSet) errors.Error {
+	labels, err := e.getMethodLabels()
 	if err != nil {
 		return err
 	}
-
+	analysis := NewErrorAnalysis(labels)
 	for _, m := range methods {
 		evalset.AddMethod(m)
+		analysis.Add(m)
+	}
+	e.report.AddMethods(methods)
+	if err := e.resultWriter.WriteErrorAnalysis(analysis); err != nil {
+		return err
 	}
 	return e.resultWriter.WriteMethods(methods)
 }
@@ -259,6 +397,7 @@ func (e *Evaluator) getGeneratedMethodsForEvaluationSet(path, checkpoint string)
 }
 
 func (e *Evaluator) writeScoreOutput(path string, evalset *EvaluationSet) errors.Error {
+	e.report.AddScores(evalset)
 	return e.resultWriter.WriteScores(evalset)
 }
 
@@ -272,5 +411,6 @@ func (e *Evaluator) writeExampleOutput(path string, checkpoint string, evalset *
 		return err
 	}
 
+	e.report.AddExamples(examples, examplesContexts, generated)
 	return e.resultWriter.WriteExamples(examples, examplesContexts, generated)
 }
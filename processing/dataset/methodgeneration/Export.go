@@ -0,0 +1,115 @@
+package methodgeneration
+
+import (
+	"bufio"
+	"encoding/json"
+	"path/filepath"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/services/predictor"
+)
+
+const (
+	TrainingSetJsonlFileName   = "methodgeneration_trainingSet.jsonl"
+	EvaluationSetJsonlFileName = "methodgeneration_evaluationSet.jsonl"
+	DatasetInfoFileName        = "dataset_info.json"
+)
+
+const (
+	TrainingSplit   = "train"
+	EvaluationSplit = "validation"
+)
+
+var ErrCouldNotExport = errors.ErrorId("Export", "Could not export dataset")
+
+// Describes the exported dataset files, so they can be used without knowing the dataset configuration.
+type DatasetInfo struct {
+	DatasetName        string                                  `json:"datasetName"`
+	Description        string                                  `json:"description,omitempty"`
+	Splits             map[string]SplitInfo                    `json:"splits"`
+	Filter             configuration.Filter                    `json:"filter"`
+	SentenceFormatting configuration.SentenceFormattingOptions `json:"sentenceFormatting"`
+	TypeRendering      configuration.TypeRendering             `json:"typeRendering,omitempty"`
+	UseJavadoc         bool                                    `json:"useJavadoc"`
+}
+
+type SplitInfo struct {
+	Name        string `json:"name"`
+	FileName    string `json:"fileName"`
+	NumExamples int    `json:"numExamples"`
+}
+
+// Writes the training and evaluation set as JSONL files containing one method per line in the format passed to the
+// predictor (including the sentence formatting of the dataset). Also writes a dataset info file next to them.
+func exportJsonl(outputDir string, dataset configuration.Dataset, trainingSet, evaluationSet []csv.MethodGenerationDatasetRow) errors.Error {
+	if err := writeJsonl(filepath.Join(outputDir, TrainingSetJsonlFileName), trainingSet, dataset.PreprocessingOptions.SentenceFormatting); err != nil {
+		return err
+	} else if err := writeJsonl(filepath.Join(outputDir, EvaluationSetJsonlFileName), evaluationSet, dataset.PreprocessingOptions.SentenceFormatting); err != nil {
+		return err
+	}
+	info := DatasetInfo{
+		DatasetName: dataset.Name(),
+		Description: dataset.Description,
+		Splits: map[string]SplitInfo{
+			TrainingSplit: {
+				Name:        TrainingSplit,
+				FileName:    TrainingSetJsonlFileName,
+				NumExamples: len(trainingSet),
+			},
+			EvaluationSplit: {
+				Name:        EvaluationSplit,
+				FileName:    EvaluationSetJsonlFileName,
+				NumExamples: len(evaluationSet),
+			},
+		},
+		Filter:             dataset.Filter,
+		SentenceFormatting: dataset.PreprocessingOptions.SentenceFormatting,
+		TypeRendering:      dataset.CreationOptions.TypeRendering,
+		UseJavadoc:         dataset.CreationOptions.UseJavadoc,
+	}
+	return writeDatasetInfo(filepath.Join(outputDir, DatasetInfoFileName), info)
+}
+
+func writeJsonl(path string, rows []csv.MethodGenerationDatasetRow, formatting configuration.SentenceFormattingOptions) errors.Error {
+	methods, err := mapToMethods(rows)
+	if err != nil {
+		return err
+	}
+	predictor.FormatMethods(methods, formatting)
+
+	file, err := utils.CreateFile(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	for _, method := range methods {
+		if err := encoder.Encode(method); err != nil {
+			return ErrCouldNotExport.Wrap(err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return ErrCouldNotExport.Wrap(err)
+	}
+	return nil
+}
+
+func writeDatasetInfo(path string, info DatasetInfo) errors.Error {
+	contents, err := json.MarshalIndent(info, "", "    ")
+	if err != nil {
+		return ErrCouldNotExport.Wrap(err)
+	}
+	file, createErr := utils.CreateFile(path)
+	if createErr != nil {
+		return createErr
+	}
+	defer file.Close()
+	if _, err := file.Write(contents); err != nil {
+		return ErrCouldNotExport.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package methodgeneration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/services/predictor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportJsonl(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	dataset := configuration.Dataset{}
+	dataset.NameRaw = "test"
+	dataset.PreprocessingOptions.SentenceFormatting.MethodName = true
+	trainingSet := []csv.MethodGenerationDatasetRow{
+		{ClassName: "a.Person", MethodName: "getName", ReturnType: "String"},
+		{ClassName: "a.Person", MethodName: "setAge", ReturnType: "void", Parameters: []string{"int/age"}},
+	}
+	evaluationSet := []csv.MethodGenerationDatasetRow{
+		{ClassName: "a.Person", MethodName: "getAge", ReturnType: "List<String>"},
+	}
+
+	// when
+	err := exportJsonl(dir, dataset, trainingSet, evaluationSet)
+
+	// then
+	assert.NoError(t, err)
+	contents, _ := os.ReadFile(filepath.Join(dir, TrainingSetJsonlFileName))
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	assert.Len(t, lines, 2)
+	var method predictor.Method
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &method))
+	assert.Equal(t, "set age", method.Context.MethodName)
+	assert.Equal(t, "age", method.Values.Parameters[0].Name)
+
+	contents, _ = os.ReadFile(filepath.Join(dir, EvaluationSetJsonlFileName))
+	assert.Contains(t, string(contents), `"returnType":"List<String>"`)
+
+	var info DatasetInfo
+	contents, _ = os.ReadFile(filepath.Join(dir, DatasetInfoFileName))
+	assert.NoError(t, json.Unmarshal(contents, &info))
+	assert.Equal(t, 2, info.Splits[TrainingSplit].NumExamples)
+	assert.Equal(t, 1, info.Splits[EvaluationSplit].NumExamples)
+}
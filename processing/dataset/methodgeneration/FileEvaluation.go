@@ -0,0 +1,174 @@
+package methodgeneration
+
+import (
+	"os"
+
+	"returntypes-langserver/common/code/java"
+	"returntypes-langserver/common/code/packagetree"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/processing/extractor"
+	"returntypes-langserver/processing/typeclasses"
+	"returntypes-langserver/services/predictor"
+)
+
+// The directory in the main output dir containing the results of file evaluations
+const FileEvaluationOutputDir = "fileEvaluation"
+
+type FileEvaluationOptions struct {
+	// The reference of a configured dataset or the name of a model of the predictor
+	Model string
+	// The checkpoint of the model. If empty, the final model is used.
+	Checkpoint string
+	// The path of an evaluation configuration file. If empty, the configured evaluation sets are used.
+	Evaluation string
+	// The maximum number of evaluated methods. If 0, all methods are evaluated.
+	Limit int
+}
+
+// Evaluates a model on the methods of a methods csv file (like the extracted methods) or of a java project directory
+// without creating a dataset. The result files are written to the output directory like the results of an evaluation
+// on a dataset.
+func EvaluateFile(inputPath, outputDir string, options FileEvaluationOptions) errors.Error {
+	dataset, err := FindModel(options.Model, options.Checkpoint)
+	if err != nil {
+		return err
+	}
+	evaluator := &Evaluator{Dataset: dataset}
+	if options.Evaluation != "" {
+		evaluation, err := configuration.LoadEvaluationConfiguration(options.Evaluation)
+		if err != nil {
+			return err
+		}
+		evaluator.subsets = evaluation.Subsets
+		if evaluator.subsets == nil {
+			evaluator.subsets = []configuration.EvaluationSet{}
+		}
+	}
+
+	records, files, classes, err := LoadMethodRecords(inputPath)
+	if err != nil {
+		return err
+	}
+	if options.Limit > 0 && options.Limit < len(records) {
+		records = records[:options.Limit]
+	}
+	methods, err := mapMethodRecords(dataset, records, files, classes)
+	if err != nil {
+		return err
+	}
+	// The labels are known from the records, so the error analysis does not depend on the extracted projects
+	evaluator.methodLabels, evaluator.methodLabelsLoaded = methodLabelsOf(records), true
+
+	log.Info("Generate %d methods using %s\n", len(methods), dataset.Name())
+	generated, err := evaluator.generateMethodDefinitions(methods, options.Checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0777); err != nil {
+		return errors.Wrap(err, "Evaluation", "Could not create the output directory %s", outputDir)
+	}
+	_, err = evaluator.writeResult(outputDir, options.Checkpoint, generated)
+	return err
+}
+
+// Returns the configured dataset with the given reference. If there is no such dataset, a dataset is created for the
+// model of the predictor with the given name (as the language server does), so models trained with other
+// configurations can be used as well.
+func FindModel(reference, checkpoint string) (configuration.Dataset, errors.Error) {
+	if dataset, err := configuration.FindDatasetByReference(reference); err == nil {
+		return dataset, nil
+	}
+	models, err := predictor.Global().GetModels(predictor.MethodGenerator)
+	if err != nil {
+		return configuration.Dataset{}, err
+	}
+	for _, model := range models {
+		if model.ModelName != reference {
+			continue
+		} else if checkpoint != "" && !utils.ContainsString(model.Checkpoints, checkpoint) {
+			return configuration.Dataset{}, errors.New("Evaluation", "The model %s has no checkpoint %s", reference, checkpoint)
+		}
+		return configuration.Dataset{
+			DatasetBase: configuration.DatasetBase{
+				NameRaw: reference,
+				PreprocessingOptions: configuration.PreprocessingOptions{
+					SentenceFormatting: configuration.SentenceFormattingOptions(model.SentenceFormattingOptions),
+				},
+				ModelOptions: configuration.ModelOptions{
+					ModelType: model.ModelType,
+				},
+			},
+		}, nil
+	}
+	return configuration.Dataset{}, errors.New("Evaluation", "There is neither a dataset nor a model named %s", reference)
+}
+
+// Loads the methods of a methods csv file or extracts the methods of a java project directory using the crawler.
+// Returns the methods, the context types of their files, which are only known for directories, and the class hierarchy
+// of the directory. For methods files, the class hierarchy of the extracted projects is returned if it exists.
+func LoadMethodRecords(path string) ([]csv.Method, []csv.FileContextTypes, []csv.Class, errors.Error) {
+	if utils.DirExists(path) {
+		log.Info("Extract methods of %s\n", path)
+		return extractor.ExtractDirectory(path)
+	} else if !utils.FileExists(path) {
+		return nil, nil, nil, errors.New("Evaluation", "The methods file %s does not exist", path)
+	}
+	methods, err := csv.NewFileReader(path).ReadMethodRecords()
+	if err != nil || !utils.FileExists(configuration.ClassHierarchyOutputPath()) {
+		return methods, nil, nil, err
+	}
+	classes, err := csv.NewFileReader(configuration.ClassHierarchyOutputPath()).ReadClassRecords()
+	return methods, nil, classes, err
+}
+
+// Maps the methods to the contexts and the expected values of the predictor in the same way as the methods of the
+// evaluation set of the dataset. If the dataset uses type classes, the types are mapped to their type classes using
+// the class hierarchy of the given classes and the default libraries.
+func mapMethodRecords(dataset configuration.Dataset, records []csv.Method, files []csv.FileContextTypes, classes []csv.Class) ([]predictor.Method, errors.Error) {
+	processor := &Processor{
+		Options: dataset.CreationOptions,
+		Dataset: dataset,
+		files:   make(map[string][]string, len(files)),
+	}
+	for _, file := range files {
+		processor.files[file.FilePath] = file.ContextTypes
+	}
+	if dataset.CreationOptions.TypeClasses != nil {
+		tree, err := createPackageTree(classes)
+		if err != nil {
+			return nil, err
+		}
+		if processor.typeClassMapper, err = typeclasses.New(tree, dataset.CreationOptions.TypeClasses); err != nil {
+			return nil, err
+		}
+	}
+	rows := make([]csv.MethodGenerationDatasetRow, len(records))
+	for i := range records {
+		// The records are copied, as the type class mapping changes the types of the method
+		method := records[i]
+		row, err := processor.mapMethod(&method)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = row
+	}
+	return mapToMethods(rows)
+}
+
+// Creates a package tree of the classes and the classes of the default libraries.
+func createPackageTree(classes []csv.Class) (*packagetree.Tree, errors.Error) {
+	for _, defaultLibrary := range configuration.DefaultLibraries() {
+		defaultClasses, err := csv.NewFileReader(defaultLibrary).ReadClassRecords()
+		if err != nil {
+			return nil, err
+		}
+		classes = append(classes, defaultClasses...)
+	}
+	tree := packagetree.New()
+	java.FillPackageTreeByCsvClassNodes(&tree, classes)
+	return &tree, nil
+}
@@ -0,0 +1,70 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapMethodRecordsOfFile(t *testing.T) {
+	// given
+	records := []csv.Method{{
+		MethodName: "findUser",
+		ReturnType: "com.example.User",
+		Parameters: []string{"java.lang.String/name", "int[]/ids"},
+		Modifier:   []string{"public", "static"},
+		ClassName:  "com.example.UserService",
+		FilePath:   "UserService.java",
+		Labels:     []string{"finder"},
+	}}
+	files := []csv.FileContextTypes{{FilePath: "UserService.java", ContextTypes: []string{"User", "UserService"}}}
+
+	// when
+	methods, err := mapMethodRecords(configuration.Dataset{}, records, files, nil)
+	labels := methodLabelsOf(records)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, methods, 1)
+	assert.Equal(t, []string{"com", "example", "UserService"}, methods[0].Context.ClassName)
+	assert.True(t, methods[0].Context.IsStatic)
+	assert.Equal(t, []string{"User", "UserService"}, methods[0].Context.Types)
+	assert.Len(t, methods[0].Values.Parameters, 2)
+	assert.Equal(t, "ids", methods[0].Values.Parameters[1].Name)
+	assert.True(t, methods[0].Values.Parameters[1].IsArray)
+	assert.Equal(t, []string{"finder"}, labels[methodLabelKey("com.example.UserService", "find user")])
+}
+
+func TestMapMethodRecordsOfFileToTypeClasses(t *testing.T) {
+	// given
+	records := []csv.Method{{
+		MethodName: "findUsers",
+		ReturnType: "java.util.ArrayList<com.example.User>",
+		Parameters: []string{"com.example.Admin/admin", "java.lang.String/name"},
+		ClassName:  "com.example.UserService",
+	}}
+	classes := []csv.Class{
+		{ClassName: "java.util.ArrayList", Extends: []string{"java.util.List"}},
+		{ClassName: "com.example.Admin", Extends: []string{"com.example.User"}},
+	}
+	dataset := configuration.Dataset{}
+	dataset.CreationOptions.TypeClasses = configuration.TypeClassConfigurations{
+		{Label: "list", Elements: []string{"java.util.List"}},
+		{Label: "user", Elements: []string{"com.example.User"}},
+		{Label: "object", Elements: []string{"java.lang.Object"}},
+	}
+
+	// when
+	methods, err := mapMethodRecords(dataset, records, nil, classes)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, methods, 1)
+	assert.Equal(t, "list", methods[0].Values.ReturnType)
+	assert.Equal(t, "user", methods[0].Values.Parameters[0].Type)
+	assert.Equal(t, "object", methods[0].Values.Parameters[1].Type)
+	assert.Equal(t, "java.util.ArrayList<com.example.User>", records[0].ReturnType)
+}
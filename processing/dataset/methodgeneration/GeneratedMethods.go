@@ -0,0 +1,91 @@
+package methodgeneration
+
+import (
+	"strings"
+
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/services/predictor"
+)
+
+// Writes the evaluated methods with all generated suggestions, so they can be rated again without the predictor.
+func WriteGeneratedMethods(path string, methods []Method) errors.Error {
+	records := make([]csv.GeneratedMethod, len(methods))
+	for i, method := range methods {
+		records[i] = csv.GeneratedMethod{
+			ClassName:          method.ClassName,
+			MethodName:         method.Name,
+			ContextTypes:       method.ContextTypes,
+			ExpectedDefinition: method.ExpectedDefinition.String(),
+		}
+		for _, suggestion := range method.suggestions(0) {
+			records[i].GeneratedDefinitions = append(records[i].GeneratedDefinitions, suggestion.String())
+		}
+	}
+	return csv.NewFileWriter(path).WriteGeneratedMethodRecords(records)
+}
+
+// Loads the methods written by WriteGeneratedMethods. The values of the methods are parsed from the definitions.
+func LoadGeneratedMethods(path string) ([]Method, errors.Error) {
+	records, err := csv.NewFileReader(path).ReadGeneratedMethodRecords()
+	if err != nil {
+		return nil, err
+	}
+	methods := make([]Method, len(records))
+	for i, record := range records {
+		methods[i] = Method{
+			Name:               record.MethodName,
+			ClassName:          record.ClassName,
+			ExpectedDefinition: metrics.NewSentence(record.ExpectedDefinition),
+			ExpectedValues:     parseDefinition(record.ExpectedDefinition),
+			Method: predictor.Method{
+				Context: predictor.MethodContext{MethodName: record.MethodName},
+			},
+		}
+		if !csv.IsEmptyList(record.ContextTypes) {
+			methods[i].ContextTypes = record.ContextTypes
+		}
+		if csv.IsEmptyList(record.GeneratedDefinitions) {
+			methods[i].GeneratedDefinition = metrics.NewSentence("")
+			continue
+		}
+		for _, definition := range record.GeneratedDefinitions {
+			methods[i].GeneratedDefinitions = append(methods[i].GeneratedDefinitions, metrics.NewSentence(definition))
+		}
+		methods[i].GeneratedDefinition = methods[i].GeneratedDefinitions[0]
+		methods[i].Method.Values = parseDefinition(record.GeneratedDefinitions[0])
+	}
+	return methods, nil
+}
+
+// Parses a definition created by Evaluator.joinParameters (like "string [tsp] name [psp] int [arr] [tsp] ids [rsp] void")
+// back to the method values.
+func parseDefinition(definition string) predictor.MethodValues {
+	values := predictor.MethodValues{Parameters: make([]predictor.Parameter, 0)}
+	words := make([]string, 0)
+	parameter := predictor.Parameter{}
+	isReturnType := false
+	for _, token := range strings.Fields(definition) {
+		switch {
+		case isReturnType:
+			words = append(words, token)
+		case token == "[arr]":
+			parameter.IsArray = true
+		case token == "[tsp]":
+			parameter.Type = strings.Join(words, " ")
+			words = words[:0]
+		case token == "[psp]" || token == "[rsp]":
+			if len(words) > 0 || parameter.Type != "" {
+				parameter.Name = strings.Join(words, " ")
+				values.Parameters = append(values.Parameters, parameter)
+			}
+			parameter, words = predictor.Parameter{}, words[:0]
+			isReturnType = token == "[rsp]"
+		default:
+			words = append(words, token)
+		}
+	}
+	values.ReturnType = strings.Join(words, " ")
+	return values
+}
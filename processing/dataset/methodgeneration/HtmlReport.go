@@ -0,0 +1,253 @@
+package methodgeneration
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/excel"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/services/predictor"
+)
+
+const ReportOutputFile = "methodgeneration_report.html"
+
+// The maximum number of methods in the table of the worst scoring methods
+const maxReportedMethods = 500
+
+// A static html report of an evaluation, which can be shared without other files. The charts are drawn as inline svg,
+// so the report can be viewed without network access.
+type HtmlReport struct {
+	Title         string
+	Sets          []reportSet
+	ExampleGroups []reportExampleGroup
+	// The methods with the lowest Rouge-L score in ascending order of the score
+	WorstMethods []reportMethod
+	// The number of rated methods
+	MethodCount int
+}
+
+type reportSet struct {
+	Name string
+	// The names of the parent sets and the set joined by " / "
+	Path string
+	// A bar chart of the first value of each rater if it is between 0 and 1 (like averages and shares)
+	Overview template.HTML
+	Raters   []reportRater
+}
+
+type reportRater struct {
+	Name   string
+	Rows   [][]string
+	Charts []template.HTML
+}
+
+type reportExampleGroup struct {
+	Label    string
+	Examples []reportExample
+}
+
+type reportExample struct {
+	Input   string
+	Outputs []string
+}
+
+type reportMethod struct {
+	Name      string
+	Expected  string
+	Generated string
+	Score     float64
+}
+
+func NewHtmlReport(title string) *HtmlReport {
+	return &HtmlReport{Title: title}
+}
+
+// Adds the rater results of the evaluation set and its subsets.
+func (r *HtmlReport) AddScores(evalset *EvaluationSet) {
+	r.addScores(evalset, "")
+}
+
+func (r *HtmlReport) addScores(evalset *EvaluationSet, parentPath string) {
+	path := evalset.Name
+	if parentPath != "" {
+		path = parentPath + " / " + evalset.Name
+	}
+	if len(evalset.Rater) > 0 {
+		set := reportSet{Name: evalset.Name, Path: path}
+		overviewLabels, overviewValues := make([]string, 0), make([]float64, 0)
+		for _, rater := range evalset.Rater {
+			result := rater.Result()
+			set.Raters = append(set.Raters, newReportRater(rater.Name(), result))
+			if _, value, ok := firstNumericValue(result); ok && value >= 0 && value <= 1 {
+				overviewLabels = append(overviewLabels, rater.Name())
+				overviewValues = append(overviewValues, value)
+			}
+		}
+		if len(overviewValues) > 0 {
+			set.Overview = svgBarChart("Overview", overviewLabels, overviewValues)
+		}
+		r.Sets = append(r.Sets, set)
+	}
+	for i := range evalset.Subsets {
+		r.addScores(&evalset.Subsets[i], path)
+	}
+}
+
+func newReportRater(name string, result [][]interface{}) reportRater {
+	rater := reportRater{Name: name}
+	for _, row := range result {
+		cells := make([]string, 0, len(row))
+		for _, value := range row {
+			if chart, ok := value.(excel.Chart); ok {
+				rater.Charts = append(rater.Charts, chartToSvg(chart))
+				continue
+			}
+			cells = append(cells, formatReportValue(value))
+		}
+		if len(cells) > 0 {
+			rater.Rows = append(rater.Rows, cells)
+		}
+	}
+	return rater
+}
+
+func formatReportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return fmt.Sprintf("%.4f", v)
+	case excel.Markdown:
+		return strings.ReplaceAll(string(v), "**", "")
+	}
+	return fmt.Sprint(value)
+}
+
+// Adds the generated outputs of the examples grouped by their labels. The parameters are the same as for
+// EvaluationResultWriter.WriteExamples.
+func (r *HtmlReport) AddExamples(exampleDefinitions []configuration.MethodExample, exampleContexts []predictor.MethodContext,
+	generatedOutputs [][]predictor.MethodValues) {
+	indexOfGroup := make(map[string]int)
+	for i, example := range exampleContexts {
+		if i >= len(exampleDefinitions) || i >= len(generatedOutputs) {
+			break
+		}
+		label := exampleDefinitions[i].Label
+		if _, ok := indexOfGroup[label]; !ok {
+			indexOfGroup[label] = len(r.ExampleGroups)
+			r.ExampleGroups = append(r.ExampleGroups, reportExampleGroup{Label: label})
+		}
+		reported := reportExample{Input: example.String()}
+		for _, generatedValues := range generatedOutputs[i] {
+			reported.Outputs = append(reported.Outputs, CreateMethodDefinition(example, generatedValues))
+		}
+		group := &r.ExampleGroups[indexOfGroup[label]]
+		group.Examples = append(group.Examples, reported)
+	}
+}
+
+// Adds the methods with the lowest Rouge-L F1 score of their generated definitions.
+func (r *HtmlReport) AddMethods(methods []Method) {
+	r.MethodCount = len(methods)
+	rated := make([]reportMethod, len(methods))
+	for i, method := range methods {
+		rated[i] = reportMethod{
+			Name:      method.Name,
+			Expected:  method.ExpectedDefinition.String(),
+			Generated: method.GeneratedDefinition.String(),
+			Score:     f1Score(metrics.RougeL(method.ExpectedDefinition, []*metrics.Sentence{method.GeneratedDefinition})),
+		}
+	}
+	sort.SliceStable(rated, func(i, j int) bool {
+		return rated[i].Score < rated[j].Score
+	})
+	if len(rated) > maxReportedMethods {
+		rated = rated[:maxReportedMethods]
+	}
+	r.WorstMethods = rated
+}
+
+func (r *HtmlReport) Write(path string) errors.Error {
+	page, err := template.New("report").Funcs(template.FuncMap{
+		"score": func(value float64) string { return fmt.Sprintf("%.4f", value) },
+	}).Parse(reportTemplate)
+	if err != nil {
+		return errors.Wrap(err, "Evaluation", "Could not parse the report template")
+	}
+	var buffer bytes.Buffer
+	if err := page.Execute(&buffer, r); err != nil {
+		return errors.Wrap(err, "Evaluation", "Could not create the report")
+	}
+	if err := ioutil.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		return errors.Wrap(err, "Evaluation", "Could not write the report to %s", path)
+	}
+	return nil
+}
+
+// Draws the first series of the excel chart as svg bar chart.
+func chartToSvg(chart excel.Chart) template.HTML {
+	if len(chart.Series) == 0 {
+		return ""
+	}
+	series := chart.Series[0]
+	labels, values := make([]string, len(series.Values)), make([]float64, len(series.Values))
+	for i, value := range series.Values {
+		if i < len(series.Categories) && series.Categories[i] != nil {
+			labels[i] = fmt.Sprint(series.Categories[i])
+		}
+		values[i], _ = toFloat(value)
+	}
+	title := ""
+	if chart.Title != nil {
+		title = chart.Title.Name
+	}
+	return svgBarChart(title, labels, values)
+}
+
+// Draws a vertical bar chart with one bar per value. The labels are shown under the bars and as tooltips.
+func svgBarChart(title string, labels []string, values []float64) template.HTML {
+	const height, chartHeight, barWidth, gap, top, minWidth = 220, 150, 28, 8, 30, 240
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = math.Max(maxValue, value)
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+	width := len(values)*(barWidth+gap) + gap
+	if width < minWidth {
+		width = minWidth
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" class="chart">`, width, height)
+	fmt.Fprintf(&svg, `<text x="%d" y="16" class="chart-title">%s</text>`, gap, template.HTMLEscapeString(title))
+	for i, value := range values {
+		barHeight := int(math.Round(math.Max(value, 0) / maxValue * chartHeight))
+		x := gap + i*(barWidth+gap)
+		label := ""
+		if i < len(labels) {
+			label = labels[i]
+		}
+		fmt.Fprintf(&svg, `<g><title>%s: %s</title>`, template.HTMLEscapeString(label), formatReportValue(value))
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" class="bar"/>`, x, top+chartHeight-barHeight, barWidth, barHeight)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" class="bar-label">%s</text></g>`, x+barWidth/2, top+chartHeight+14, template.HTMLEscapeString(shortLabel(label)))
+	}
+	svg.WriteString(`</svg>`)
+	return template.HTML(svg.String())
+}
+
+// Shortens the label, so it fits under a bar. The full label is shown in the tooltip.
+func shortLabel(label string) string {
+	if runes := []rune(label); len(runes) > 6 {
+		return string(runes[:5]) + "…"
+	}
+	return label
+}
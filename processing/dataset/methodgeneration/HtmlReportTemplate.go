@@ -0,0 +1,77 @@
+package methodgeneration
+
+// The template of the html report. The styles and the script for searching the methods are inlined, so the report is
+// a single file.
+const reportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1, h2, h3 { font-weight: normal; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #ababab; }
+td.number { text-align: right; font-family: monospace; }
+code { white-space: pre-wrap; }
+.chart { margin: 0 1em 1em 0; background: #fafafa; border: 1px solid #ddd; }
+.chart-title { font-size: 12px; font-weight: bold; }
+.bar { fill: #4e79a7; }
+.bar-label { font-size: 9px; text-anchor: middle; }
+.set { margin-bottom: 2em; }
+#method-search { width: 30em; padding: 4px; margin-bottom: 1em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.MethodCount}} evaluated methods</p>
+
+<h2>Scores</h2>
+{{range .Sets}}
+<div class="set">
+<h3>Set: {{.Path}}</h3>
+{{if .Overview}}{{.Overview}}{{end}}
+{{range .Raters}}
+<table>
+<tr><th colspan="2">{{.Name}}</th></tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}
+</table>
+{{range .Charts}}{{.}}{{end}}
+{{end}}
+</div>
+{{end}}
+
+{{if .ExampleGroups}}
+<h2>Examples</h2>
+{{range .ExampleGroups}}
+<h3>{{if .Label}}{{.Label}}{{else}}Examples{{end}}</h3>
+<table>
+<tr><th>Input</th><th>Generated outputs</th></tr>
+{{range .Examples}}<tr><td><code>{{.Input}}</code></td><td>{{range .Outputs}}<code>{{.}}</code><br>{{end}}</td></tr>
+{{end}}
+</table>
+{{end}}
+{{end}}
+
+<h2>Worst scoring methods</h2>
+<p>The {{len .WorstMethods}} methods with the lowest Rouge-L F1 score of the generated definition.</p>
+<input id="method-search" type="search" placeholder="Search methods..." oninput="searchMethods(this.value)">
+<table id="methods">
+<tr><th>Method name</th><th>Expected definition</th><th>Generated definition</th><th>Rouge-L F1</th></tr>
+{{range .WorstMethods}}<tr><td>{{.Name}}</td><td><code>{{.Expected}}</code></td><td><code>{{.Generated}}</code></td><td class="number">{{score .Score}}</td></tr>
+{{end}}
+</table>
+<script>
+function searchMethods(query) {
+	query = query.toLowerCase();
+	var rows = document.getElementById("methods").rows;
+	for (var i = 1; i < rows.length; i++) {
+		rows[i].style.display = rows[i].textContent.toLowerCase().indexOf(query) >= 0 ? "" : "none";
+	}
+}
+</script>
+</body>
+</html>
+`
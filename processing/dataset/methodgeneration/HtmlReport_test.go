@@ -0,0 +1,46 @@
+package methodgeneration
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/services/predictor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHtmlReport(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), ReportOutputFile)
+	report := NewHtmlReport("test <dataset>")
+	methods := []Method{
+		methodWithSuggestions("[rsp] string", "[rsp] string"),
+		methodWithSuggestions("name [tsp] string [rsp] void", "[rsp] int"),
+	}
+	methods[0].Name, methods[1].Name = "get name", "set name"
+	evalset := EvaluationSet{Name: "all", Rater: []Metric{&ExactRater{}, &TokenCounter{}}}
+	for _, method := range methods {
+		evalset.AddMethod(method)
+	}
+
+	// when
+	report.AddMethods(methods)
+	report.AddScores(&evalset)
+	report.AddExamples([]configuration.MethodExample{{MethodName: "getName", Label: "getter"}},
+		[]predictor.MethodContext{{MethodName: "get name"}},
+		[][]predictor.MethodValues{{{ReturnType: "string"}}})
+	err := report.Write(path)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "set name", report.WorstMethods[0].Name)
+	assert.Len(t, report.Sets, 1)
+	assert.NotEmpty(t, report.Sets[0].Overview)
+	assert.NotEmpty(t, report.Sets[0].Raters[1].Charts)
+	contents, _ := ioutil.ReadFile(path)
+	assert.Contains(t, string(contents), "test &lt;dataset&gt;")
+	assert.Contains(t, string(contents), "<svg")
+	assert.Contains(t, string(contents), "getter")
+}
@@ -1,6 +1,7 @@
 package methodgeneration
 
 import (
+	"fmt"
 	"path/filepath"
 	"returntypes-langserver/common/code/java"
 	"returntypes-langserver/common/code/packagetree"
@@ -10,6 +11,7 @@ import (
 	"returntypes-langserver/common/debug/log"
 	"returntypes-langserver/common/utils"
 	"returntypes-langserver/processing/dataset/base"
+	"returntypes-langserver/processing/projects"
 	"returntypes-langserver/processing/typeclasses"
 	"strings"
 )
@@ -24,16 +26,32 @@ type Processor struct {
 	rows            []csv.MethodGenerationDatasetRow
 	methods         utils.StringSet
 	Options         configuration.DatasetCreationOptions
+	Dataset         configuration.Dataset
 	typeClassMapper typeclasses.Mapper
 	skip            bool
 	files           map[string][]string
+	splitter        *Splitter
+	// The group key and fallback group key of each row used for splitting
+	groupKeys         []string
+	fallbackGroupKeys []string
+	sampler   *Sampler
+	augmenter *Augmenter
+	// The type class and sampling weight of each row
+	typeClasses []string
+	weights     []float64
+	splitSizes  map[string]int
 }
 
-func NewProcessor(outputDir string, options configuration.DatasetCreationOptions, tree *packagetree.Tree) (base.MethodProcessor, errors.Error) {
+func NewProcessor(outputDir string, dataset configuration.Dataset, tree *packagetree.Tree) (base.MethodProcessor, errors.Error) {
+	options := dataset.CreationOptions
 	processor := &Processor{
 		OutputDir: outputDir,
 		Options:   options,
+		Dataset:   dataset,
 		methods:   make(utils.StringSet),
+		splitter:  NewSplitter(options.Split, options.DatasetSize, projects.GetProjects()),
+		sampler:   NewSampler(options.Sampling),
+		augmenter: NewAugmenter(options.Augmentation),
 	}
 	if utils.FileExists(processor.trainingFilePath()) {
 		processor.skip = true
@@ -69,29 +87,48 @@ func (p *Processor) Process(method *csv.Method) (isFiltered bool, err errors.Err
 			p.methods.Put(identifier)
 		}
 	}
+	row, err := p.mapMethod(method)
+	if err != nil {
+		return false, err
+	}
+	p.rows = append(p.rows, row)
+	p.groupKeys = append(p.groupKeys, p.splitter.GetGroupKey(method))
+	p.fallbackGroupKeys = append(p.fallbackGroupKeys, p.splitter.GetFallbackGroupKey(method))
+	p.typeClasses = append(p.typeClasses, TypeClassOf(row))
+	p.weights = append(p.weights, p.sampler.Weight(method.Labels))
+	return false, nil
+}
+
+// Maps the method to a dataset row. If type classes are configured, the types of the method are mapped to their
+// type classes before.
+func (p *Processor) mapMethod(method *csv.Method) (csv.MethodGenerationDatasetRow, errors.Error) {
 	if p.Options.TypeClasses != nil && p.typeClassMapper != nil {
 		if err := p.mapTypeToTypeClasses(method); err != nil {
-			return false, err
+			return csv.MethodGenerationDatasetRow{}, err
 		}
 	}
-	p.rows = append(p.rows, p.mapMethodToDatasetRow(method))
-	return false, nil
+	return p.mapMethodToDatasetRow(method), nil
 }
 
 func (p *Processor) mapMethodToDatasetRow(method *csv.Method) csv.MethodGenerationDatasetRow {
 	datasetRow := csv.MethodGenerationDatasetRow{
 		ClassName:    method.ClassName,
 		MethodName:   method.MethodName,
-		ReturnType:   utils.GetStringExtension(method.ReturnType, "."),
-		Parameters:   method.Parameters,
+		ReturnType:   RenderTypeName(method.ReturnType, p.Options.TypeRendering),
+		Parameters:   RenderParameterTypes(method.Parameters, p.Options.TypeRendering),
 		ContextTypes: p.getContextTypes(*method),
 		IsStatic:     utils.ContainsString(method.Modifier, "static"),
 	}
+	if p.Options.UseJavadoc {
+		datasetRow.JavadocSummary = method.JavadocSummary
+		datasetRow.JavadocParameters = method.JavadocParameters
+		datasetRow.JavadocReturn = method.JavadocReturn
+	}
 	return datasetRow
 }
 
 func (p *Processor) mapTypeToTypeClasses(method *csv.Method) errors.Error {
-	if returnType, err := p.typeClassMapper.MapReturnTypeToTypeClass(method.ReturnType, method.Labels); err != nil {
+	if returnType, err := p.typeClassMapper.MapReturnTypeToTypeClass(java.EraseTypeArguments(method.ReturnType), method.Labels); err != nil {
 		return err
 	} else {
 		method.ReturnType = returnType
@@ -120,7 +157,7 @@ func (p *Processor) mapParameterTypesToTypeClasses(rawParameters []string) ([]st
 		if parameter.Type.IsArrayType {
 			labels = []string{string(java.ArrayType)}
 		}
-		if typeClass, err := p.typeClassMapper.MapParameterTypeToTypeClass(parameter.Type.TypeName, labels); err != nil {
+		if typeClass, err := p.typeClassMapper.MapParameterTypeToTypeClass(java.EraseTypeArguments(parameter.Type.TypeName), labels); err != nil {
 			return nil, err
 		} else {
 			parameters[i].Type.TypeName = typeClass
@@ -129,24 +166,141 @@ func (p *Processor) mapParameterTypesToTypeClasses(rawParameters []string) ([]st
 	return java.FormatParameterList(parameters, nil), nil
 }
 
+// Renders a type name as it is written to the dataset: The type arguments are rendered as defined by the rendering mode
+// and all type names are shortened to their simple names.
+func RenderTypeName(typeName string, rendering configuration.TypeRendering) string {
+	return java.FormatSimpleTypeName(typeName, rendering)
+}
+
+// Renders the parameter types like RenderTypeName. Parameters which cannot be parsed are returned unchanged.
+func RenderParameterTypes(rawParameters []string, rendering configuration.TypeRendering) []string {
+	if csv.IsEmptyList(rawParameters) {
+		return rawParameters
+	}
+
+	parameters, err := java.ParseParameterList(rawParameters)
+	if err != nil {
+		return rawParameters
+	}
+	for i := range parameters {
+		parameters[i].Type.TypeName = RenderTypeName(parameters[i].Type.TypeName, rendering)
+	}
+	return java.FormatParameterList(parameters, nil)
+}
+
 func (p *Processor) getIdentifier(method *csv.Method) string {
 	return strings.Join([]string{method.ClassName, method.MethodName}, ".")
 }
 
 func (p *Processor) Close() errors.Error {
 	log.Info("Close dataset file at %s\n", p.trainingFilePath())
-	proportion := p.Options.DatasetSize
-	trainingSetSize, _ := utils.FitProportions(proportion.Training, proportion.Evaluation, len(p.rows))
-	trainingSet, evaluationSet := p.rows[:trainingSetSize], p.rows[trainingSetSize:]
+	if p.Options.UseJavadoc && len(p.rows) > 0 && !containsJavadoc(p.rows) {
+		return errors.New("Dataset Creation Error", "Could not create dataset %s: The dataset uses javadoc, but none of its methods has javadoc. "+
+			"The crawler probably does not output the javadoc comments of methods.", p.Dataset.QualifiedIdentifier())
+	}
+	distribution := newTypeClassDistribution()
+	distribution.count(sequence(len(p.rows)), p.typeClasses, func(d *csv.TypeClassDistribution) *int { return &d.Methods })
+	if p.sampler.IsActive() {
+		p.undersample()
+	}
+	distribution.count(sequence(len(p.rows)), p.typeClasses, func(d *csv.TypeClassDistribution) *int { return &d.SampledMethods })
 
-	if err := csv.NewFileWriter(p.trainingFilePath()).WriteMethodGenerationDatasetRowRecords(trainingSet); err != nil {
+	trainingIndices, evaluationIndices := p.splitter.SplitIndices(len(p.rows), p.groupKeys, p.fallbackGroupKeys)
+	trainingIndices = p.sampler.Oversample(trainingIndices, p.typeClasses, p.weights, p.Options.DatasetSize)
+	distribution.count(trainingIndices, p.typeClasses, func(d *csv.TypeClassDistribution) *int { return &d.TrainingSet })
+	distribution.count(evaluationIndices, p.typeClasses, func(d *csv.TypeClassDistribution) *int { return &d.EvaluationSet })
+	// Augmented rows are only added to the training set
+	trainingSet := p.augmenter.Augment(selectRows(p.rows, trainingIndices))
+	evaluationSet := selectRows(p.rows, evaluationIndices)
+	if err := p.writeDatasetFiles(p.OutputDir, p.Dataset, trainingSet, evaluationSet); err != nil {
 		return err
-	} else if err := csv.NewFileWriter(p.OutputDir, EvaluationSetFileName).WriteMethodGenerationDatasetRowRecords(evaluationSet); err != nil {
+	} else if err := csv.NewFileWriter(p.OutputDir, TypeClassDistributionFileName).WriteTypeClassDistributionRecords(distribution.records()); err != nil {
 		return err
 	}
+	p.splitSizes = map[string]int{
+		TrainingSetFileName:   len(trainingSet),
+		EvaluationSetFileName: len(evaluationSet),
+	}
+	if p.Options.CrossValidation.IsActive() {
+		return p.writeFolds()
+	}
 	return nil
 }
 
+// Returns true if at least one of the rows contains javadoc.
+func containsJavadoc(rows []csv.MethodGenerationDatasetRow) bool {
+	for _, row := range rows {
+		if mapToDocumentation(row) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Removes the rows which are not kept by the undersampling of the sampler.
+func (p *Processor) undersample() {
+	indices := p.sampler.Undersample(p.typeClasses, p.weights)
+	rows := make([]csv.MethodGenerationDatasetRow, len(indices))
+	groupKeys := make([]string, len(indices))
+	fallbackGroupKeys := make([]string, len(indices))
+	typeClasses := make([]string, len(indices))
+	weights := make([]float64, len(indices))
+	for i, index := range indices {
+		rows[i] = p.rows[index]
+		groupKeys[i] = p.groupKeys[index]
+		fallbackGroupKeys[i] = p.fallbackGroupKeys[index]
+		typeClasses[i] = p.typeClasses[index]
+		weights[i] = p.weights[index]
+	}
+	p.rows, p.groupKeys, p.fallbackGroupKeys, p.typeClasses, p.weights = rows, groupKeys, fallbackGroupKeys, typeClasses, weights
+}
+
+// Writes a training and evaluation set for each fold of the cross validation. The evaluation set of a fold contains the
+// methods of the fold itself while the training set contains the methods of all other folds.
+func (p *Processor) writeFolds() errors.Error {
+	folds := p.splitter.SplitIndicesIntoFolds(len(p.rows), p.groupKeys, p.Options.CrossValidation.Folds)
+	proportion := configuration.DatasetProportion{
+		Training:   float64(len(folds) - 1),
+		Evaluation: 1,
+	}
+	foldDatasets := p.Dataset.Folds()
+	for i := range folds {
+		trainingIndices := make([]int, 0, len(p.rows)-len(folds[i]))
+		for j := range folds {
+			if i != j {
+				trainingIndices = append(trainingIndices, folds[j]...)
+			}
+		}
+		trainingIndices = p.sampler.Oversample(trainingIndices, p.typeClasses, p.weights, proportion)
+		trainingSet := p.augmenter.Augment(selectRows(p.rows, trainingIndices))
+		if err := p.writeDatasetFiles(FoldPath(p.OutputDir, i), foldDatasets[i], trainingSet, selectRows(p.rows, folds[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Processor) writeDatasetFiles(outputDir string, dataset configuration.Dataset, trainingSet, evaluationSet []csv.MethodGenerationDatasetRow) errors.Error {
+	if err := csv.NewFileWriter(outputDir, TrainingSetFileName).WriteMethodGenerationDatasetRowRecords(trainingSet); err != nil {
+		return err
+	} else if err := csv.NewFileWriter(outputDir, EvaluationSetFileName).WriteMethodGenerationDatasetRowRecords(evaluationSet); err != nil {
+		return err
+	}
+	if p.Options.ExportJsonl {
+		return exportJsonl(outputDir, dataset, trainingSet, evaluationSet)
+	}
+	return nil
+}
+
+// Returns the path of the directory containing the dataset files of the fold with the given (zero based) index.
+func FoldPath(path string, fold int) string {
+	return filepath.Join(path, fmt.Sprintf("fold%d", fold+1))
+}
+
+func (p *Processor) SplitSizes() map[string]int {
+	return p.splitSizes
+}
+
 func (p *Processor) trainingFilePath() string {
 	return filepath.Join(p.OutputDir, TrainingSetFileName)
 }
@@ -156,7 +310,7 @@ func (p *Processor) getContextTypes(method csv.Method) []string {
 	if val, ok := p.files[method.FilePath]; ok {
 		contextTypes = append(contextTypes, val...)
 	}
-	contextTypes = p.appendContextType(contextTypes, method.ReturnType)
+	contextTypes = p.appendContextType(contextTypes, java.EraseTypeArguments(method.ReturnType))
 	if parameters, err := java.ParseParameterList(method.Parameters); err != nil {
 		for _, par := range parameters {
 			contextTypes = p.appendContextType(contextTypes, par.Type.TypeName)
@@ -0,0 +1,39 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderParameterTypesShortensResolvedTypeArguments(t *testing.T) {
+	// given
+	parameters := []string{"java.util.Map<java.lang.String, java.util.List<com.example.User>>/users", "com.example.User[]/others"}
+
+	// when
+	shallow := RenderParameterTypes(parameters, configuration.ShallowGenericTypes)
+	full := RenderParameterTypes(parameters, configuration.FullGenericTypes)
+
+	// then
+	assert.Equal(t, []string{"Map<String, List>/users", "User[]/others"}, shallow)
+	assert.Equal(t, []string{"Map<String, List<User>>/users", "User[]/others"}, full)
+}
+
+func TestCloseFailsIfNoMethodHasJavadoc(t *testing.T) {
+	// given
+	processor := &Processor{
+		OutputDir: t.TempDir(),
+		Options:   configuration.DatasetCreationOptions{UseJavadoc: true},
+		Dataset:   configuration.Dataset{DatasetBase: configuration.DatasetBase{NameRaw: "javadoc"}},
+		rows:      []csv.MethodGenerationDatasetRow{{MethodName: "get name", ReturnType: "String"}},
+	}
+
+	// when
+	err := processor.Close()
+
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package methodgeneration
+
+import (
+	"fmt"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/common/utils"
+
+	"github.com/waygo/bleu"
+)
+
+// Returns the first k generated suggestions of the method or all suggestions if k is 0.
+func (m Method) suggestions(k int) []*metrics.Sentence {
+	suggestions := m.GeneratedDefinitions
+	if len(suggestions) == 0 && m.GeneratedDefinition != nil {
+		suggestions = []*metrics.Sentence{m.GeneratedDefinition}
+	}
+	if k > 0 && k < len(suggestions) {
+		return suggestions[:k]
+	}
+	return suggestions
+}
+
+// Returns the rank (starting at 1) of the first suggestion matching the expected definition or 0 if there is none.
+func (m Method) rankOfExactMatch(k int) int {
+	for i, suggestion := range m.suggestions(k) {
+		if isExactMatch(suggestion, m.ExpectedDefinition) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func formatK(k int) string {
+	if k <= 0 {
+		return "all"
+	}
+	return fmt.Sprintf("%d", k)
+}
+
+type ExactMatchAtKRater struct {
+	config  configuration.ExactMatchAtKConfiguration
+	matches float64
+	count   float64
+}
+
+func NewExactMatchAtKRater(config configuration.MetricConfiguration) (*ExactMatchAtKRater, errors.Error) {
+	c, err := config.AsExactMatchAtK()
+	if err != nil {
+		return nil, err
+	}
+	return &ExactMatchAtKRater{config: c}, nil
+}
+
+func (r *ExactMatchAtKRater) Rate(m Method) {
+	if m.rankOfExactMatch(r.config.K) > 0 {
+		r.matches++
+	}
+	r.count++
+}
+
+func (r *ExactMatchAtKRater) Result() [][]interface{} {
+	return [][]interface{}{{"Average", r.matches / r.count},
+		{"Matches", r.matches},
+		{"Overall count", r.count}}
+}
+
+func (r *ExactMatchAtKRater) Name() string {
+	return fmt.Sprintf("Exact matches@%s", formatK(r.config.K))
+}
+
+type MeanReciprocalRankRater struct {
+	config            configuration.MeanReciprocalRankConfiguration
+	reciprocalRankSum float64
+	count             float64
+	matchesPerRank    []int
+}
+
+func NewMeanReciprocalRankRater(config configuration.MetricConfiguration) (*MeanReciprocalRankRater, errors.Error) {
+	c, err := config.AsMeanReciprocalRank()
+	if err != nil {
+		return nil, err
+	}
+	return &MeanReciprocalRankRater{config: c}, nil
+}
+
+func (r *MeanReciprocalRankRater) Rate(m Method) {
+	if rank := m.rankOfExactMatch(r.config.K); rank > 0 {
+		r.reciprocalRankSum += 1 / float64(rank)
+		for len(r.matchesPerRank) < rank {
+			r.matchesPerRank = append(r.matchesPerRank, 0)
+		}
+		r.matchesPerRank[rank-1]++
+	}
+	r.count++
+}
+
+func (r *MeanReciprocalRankRater) Result() [][]interface{} {
+	result := [][]interface{}{{"Mean reciprocal rank", r.reciprocalRankSum / r.count},
+		{"Overall count", r.count}}
+	for i, matches := range r.matchesPerRank {
+		result = append(result, []interface{}{fmt.Sprintf("First match at rank %d", i+1), matches})
+	}
+	return result
+}
+
+func (r *MeanReciprocalRankRater) Name() string {
+	return fmt.Sprintf("Mean reciprocal rank@%s", formatK(r.config.K))
+}
+
+// Scores each of the first k suggestions and uses the best score of them. This corresponds to a user picking the
+// best suggestion from the completion list.
+type BestOfKRater struct {
+	config    configuration.BestOfKConfiguration
+	scorer    func(generated, expected *metrics.Sentence) float64
+	bestSum   float64
+	firstSum  float64
+	count     float64
+	bestRanks []int
+}
+
+func NewBestOfKRater(config configuration.MetricConfiguration) (*BestOfKRater, errors.Error) {
+	c, err := config.AsBestOfK()
+	if err != nil {
+		return nil, err
+	}
+	rater := &BestOfKRater{config: c}
+	switch c.Metric {
+	case "", configuration.RougeL:
+		rater.config.Metric = configuration.RougeL
+		rater.scorer = func(generated, expected *metrics.Sentence) float64 {
+			return f1Score(metrics.RougeL(expected, []*metrics.Sentence{generated}))
+		}
+	case configuration.RougeN:
+		if rater.config.N <= 0 {
+			rater.config.N = 2
+		}
+		n := rater.config.N
+		rater.scorer = func(generated, expected *metrics.Sentence) float64 {
+			return f1Score(metrics.RougeN(expected, []*metrics.Sentence{generated}, n))
+		}
+	case configuration.Bleu:
+		weights := []float64{0.25, 0.25, 0.25, 0.25}
+		rater.scorer = func(generated, expected *metrics.Sentence) float64 {
+			return bleu.Smooth(generated.Tokens(), []bleu.Sentence{expected.Tokens()}, weights)
+		}
+	default:
+		return nil, errors.New("Evaluation", "Unsupported metric for best of k: %s", c.Metric)
+	}
+	return rater, nil
+}
+
+func f1Score(precision, recall float64) float64 {
+	return metrics.FScore(precision, recall, 1)
+}
+
+func (r *BestOfKRater) Rate(m Method) {
+	best, bestRank := 0.0, 0
+	for i, suggestion := range m.suggestions(r.config.K) {
+		score := r.scorer(suggestion, m.ExpectedDefinition)
+		if i == 0 {
+			r.firstSum += score
+		}
+		if i == 0 || score > best {
+			best, bestRank = score, i+1
+		}
+	}
+	r.bestSum += best
+	if bestRank > 0 {
+		for len(r.bestRanks) < bestRank {
+			r.bestRanks = append(r.bestRanks, 0)
+		}
+		r.bestRanks[bestRank-1]++
+	}
+	r.count++
+}
+
+func (r *BestOfKRater) Result() [][]interface{} {
+	result := [][]interface{}{{"Best score average", r.bestSum / r.count},
+		{"First suggestion average", r.firstSum / r.count},
+		{"Overall count", r.count}}
+	for i, count := range r.bestRanks {
+		result = append(result, []interface{}{fmt.Sprintf("Best suggestion at rank %d", i+1), count})
+	}
+	return result
+}
+
+func (r *BestOfKRater) Name() string {
+	metric := r.config.Metric
+	if metric == configuration.RougeN {
+		metric = fmt.Sprintf("rouge-%d", r.config.N)
+	}
+	return fmt.Sprintf("Best of %s (%s)", formatK(r.config.K), metric)
+}
+
+// Measures how different the suggestions of a method are. Identical suggestions waste space in the completion list.
+type DiversityRater struct {
+	config              configuration.DiversityConfiguration
+	distinctSuggestions float64
+	distinctUnigrams    float64
+	distinctBigrams     float64
+	suggestionCount     float64
+	count               float64
+}
+
+func NewDiversityRater(config configuration.MetricConfiguration) (*DiversityRater, errors.Error) {
+	c, err := config.AsDiversity()
+	if err != nil {
+		return nil, err
+	}
+	return &DiversityRater{config: c}, nil
+}
+
+func (r *DiversityRater) Rate(m Method) {
+	suggestions := m.suggestions(r.config.K)
+	if len(suggestions) == 0 {
+		return
+	}
+	definitions := make(utils.StringSet)
+	unigrams, bigrams := make([]string, 0), make([]string, 0)
+	for _, suggestion := range suggestions {
+		definitions.Put(suggestion.String())
+		unigrams = append(unigrams, suggestion.Ngram(1)...)
+		bigrams = append(bigrams, suggestion.Ngram(2)...)
+	}
+	r.distinctSuggestions += float64(len(definitions)) / float64(len(suggestions))
+	r.distinctUnigrams += distinctRatio(unigrams)
+	r.distinctBigrams += distinctRatio(bigrams)
+	r.suggestionCount += float64(len(suggestions))
+	r.count++
+}
+
+// Returns the share of distinct values or 1 if there are no values.
+func distinctRatio(values []string) float64 {
+	if len(values) == 0 {
+		return 1
+	}
+	distinct := make(utils.StringSet)
+	for _, value := range values {
+		distinct.Put(value)
+	}
+	return float64(len(distinct)) / float64(len(values))
+}
+
+func (r *DiversityRater) Result() [][]interface{} {
+	return [][]interface{}{{"Distinct suggestions", r.distinctSuggestions / r.count},
+		{"Distinct unigrams", r.distinctUnigrams / r.count},
+		{"Distinct bigrams", r.distinctBigrams / r.count},
+		{"Average suggestions per method", r.suggestionCount / r.count},
+		{"Overall count", r.count}}
+}
+
+func (r *DiversityRater) Name() string {
+	return fmt.Sprintf("Diversity of %s suggestions", formatK(r.config.K))
+}
@@ -0,0 +1,64 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func methodWithSuggestions(expected string, suggestions ...string) Method {
+	method := Method{ExpectedDefinition: metrics.NewSentence(expected)}
+	for _, suggestion := range suggestions {
+		method.GeneratedDefinitions = append(method.GeneratedDefinitions, metrics.NewSentence(suggestion))
+	}
+	method.GeneratedDefinition = method.GeneratedDefinitions[0]
+	return method
+}
+
+func TestExactMatchAtKAndMeanReciprocalRank(t *testing.T) {
+	// given
+	exactMatch, err := NewExactMatchAtKRater(configuration.MetricConfiguration{"type": configuration.ExactMatchAtK, "k": 2})
+	assert.NoError(t, err)
+	reciprocalRank, err := NewMeanReciprocalRankRater(configuration.MetricConfiguration{"type": configuration.MeanReciprocalRank})
+	assert.NoError(t, err)
+	methods := []Method{
+		methodWithSuggestions("[rsp] string", "[rsp] string", "[rsp] int", "[rsp] void"),
+		methodWithSuggestions("[rsp] int", "[rsp] string", "[rsp] int", "[rsp] void"),
+		methodWithSuggestions("[rsp] void", "[rsp] string", "[rsp] int", "[rsp] void"),
+		methodWithSuggestions("[rsp] boolean", "[rsp] string", "[rsp] int", "[rsp] void"),
+	}
+
+	// when
+	for _, method := range methods {
+		exactMatch.Rate(method)
+		reciprocalRank.Rate(method)
+	}
+
+	// then
+	assert.Equal(t, 0.5, exactMatch.Result()[0][1])
+	assert.InDelta(t, (1+1.0/2+1.0/3)/4, reciprocalRank.Result()[0][1], 0.0001)
+	assert.Equal(t, []int{1, 1, 1}, reciprocalRank.matchesPerRank)
+}
+
+func TestBestOfKAndDiversity(t *testing.T) {
+	// given
+	bestOfK, err := NewBestOfKRater(configuration.MetricConfiguration{"type": configuration.BestOfK, "metric": configuration.RougeL})
+	assert.NoError(t, err)
+	diversity, err := NewDiversityRater(configuration.MetricConfiguration{"type": configuration.Diversity, "k": 2})
+	assert.NoError(t, err)
+	method := methodWithSuggestions("[rsp] string", "[rsp] int", "[rsp] int", "[rsp] string")
+
+	// when
+	bestOfK.Rate(method)
+	diversity.Rate(method)
+
+	// then
+	assert.Equal(t, 1.0, bestOfK.bestSum)
+	assert.Less(t, bestOfK.firstSum, 1.0)
+	assert.Equal(t, []int{0, 0, 1}, bestOfK.bestRanks)
+	assert.Equal(t, 0.5, diversity.distinctSuggestions)
+	assert.Equal(t, 2.0, diversity.suggestionCount)
+}
@@ -10,6 +10,7 @@ import (
 	"returntypes-langserver/common/metrics"
 	"returntypes-langserver/common/utils"
 	"returntypes-langserver/services/predictor"
+	"sort"
 	"strings"
 
 	"github.com/waygo/bleu"
@@ -285,13 +286,14 @@ type ExactRater struct {
 }
 
 func (r *ExactRater) Rate(m Method) {
-	if r.isMatching(m.GeneratedDefinition.Tokens(), m.ExpectedDefinition.Tokens()) {
+	if isExactMatch(m.GeneratedDefinition, m.ExpectedDefinition) {
 		r.matches++
 	}
 	r.count++
 }
 
-func (r *ExactRater) isMatching(generated, expected []string) bool {
+func isExactMatch(generatedDefinition, expectedDefinition *metrics.Sentence) bool {
+	generated, expected := generatedDefinition.Tokens(), expectedDefinition.Tokens()
 	if len(generated) != len(expected) {
 		return false
 	}
@@ -333,15 +335,10 @@ func (r *CompilabilityRater) hasCompileErrors(method predictor.Method) bool {
 	//   - for example, parts of the tokens like [arr] and so on should not be present.
 	//   - this checks also, if the tokens are empty
 	// - parameter names do not overlap with other parameter names
-	parameterNames := make(utils.StringSet)
+	if !hasValidParameterNames(method.Values.Parameters) {
+		return true
+	}
 	for _, par := range method.Values.Parameters {
-		concatenatedName := ConcatByLowerCamelCase(strings.Split(par.Name, " "))
-		if parameterNames.Has(concatenatedName) || !r.isValidParameterName(concatenatedName) {
-			return true
-		} else {
-			parameterNames.Put(concatenatedName)
-		}
-
 		if !r.isValidParameterType(par.Type) {
 			return true
 		}
@@ -361,7 +358,7 @@ func (r *CompilabilityRater) isValidReturnType(identifier string) bool {
 	return TokenMatcher.Match([]byte(concatenated)) && !utils.ContainsString(otherKeywords, identifier)
 }
 
-func (r *CompilabilityRater) isValidParameterName(concatenated string) bool {
+func isValidParameterName(concatenated string) bool {
 	return TokenMatcher.Match([]byte(concatenated)) && !utils.ContainsString(otherKeywords, concatenated) &&
 		!utils.ContainsString(typeKeywords, concatenated) && concatenated != "void"
 }
@@ -381,3 +378,113 @@ func (r *CompilabilityRater) Result() [][]interface{} {
 func (r *CompilabilityRater) Name() string {
 	return fmt.Sprintf("Compilability rate")
 }
+
+// The maximum number of unresolved types listed in the result
+const maxUnresolvedTypeRows = 100
+
+// Checks if the generated types exist in the context of the method (in contrast to the compilability rater, which only
+// checks the shape of the tokens) and if the parameter names are unique and not java keywords.
+type TypeResolutionRater struct {
+	resolver        *TypeResolver
+	resolvedCount   float64
+	validNamesCount float64
+	validCount      float64
+	count           float64
+	unresolvedTypes map[string]int
+}
+
+func NewTypeResolutionRater(config configuration.MetricConfiguration, resolver *TypeResolver) (*TypeResolutionRater, errors.Error) {
+	if _, err := config.AsTypeResolution(); err != nil {
+		return nil, err
+	}
+	return &TypeResolutionRater{
+		resolver:        resolver,
+		unresolvedTypes: make(map[string]int),
+	}, nil
+}
+
+func (r *TypeResolutionRater) Rate(m Method) {
+	isResolved := r.resolveTypes(m)
+	hasValidNames := hasValidParameterNames(m.Method.Values.Parameters)
+	if isResolved {
+		r.resolvedCount++
+	}
+	if hasValidNames {
+		r.validNamesCount++
+	}
+	if isResolved && hasValidNames {
+		r.validCount++
+	}
+	r.count++
+}
+
+// Returns true if the return type and all parameter types are resolved. Unresolved types are counted.
+func (r *TypeResolutionRater) resolveTypes(m Method) bool {
+	context := r.resolver.Context(m.ClassName, m.ContextTypes)
+	isResolved := true
+	if typeName, ok := r.resolver.Resolve(m.Method.Values.ReturnType, context); !ok {
+		r.unresolvedTypes[typeName]++
+		isResolved = false
+	}
+	for _, par := range m.Method.Values.Parameters {
+		if typeNameKey(par.Type) == "void" {
+			r.unresolvedTypes["void"]++
+			isResolved = false
+		} else if typeName, ok := r.resolver.Resolve(par.Type, context); !ok {
+			r.unresolvedTypes[typeName]++
+			isResolved = false
+		}
+	}
+	return isResolved
+}
+
+// Returns true if the parameter names are valid identifiers and do not overlap with each other.
+func hasValidParameterNames(parameters []predictor.Parameter) bool {
+	parameterNames := make(utils.StringSet)
+	for _, par := range parameters {
+		concatenatedName := ConcatByLowerCamelCase(strings.Split(par.Name, " "))
+		if parameterNames.Has(concatenatedName) || !isValidParameterName(concatenatedName) {
+			return false
+		}
+		parameterNames.Put(concatenatedName)
+	}
+	return true
+}
+
+func (r *TypeResolutionRater) Result() [][]interface{} {
+	result := [][]interface{}{{"Average", r.validCount / r.count},
+		{"Signatures with resolved types", r.resolvedCount / r.count},
+		{"Signatures with valid parameter names", r.validNamesCount / r.count},
+		{"Valid methods", r.validCount},
+		{"Overall count", r.count},
+		{},
+		{excel.Markdown("**Unresolved type**"), excel.Markdown("**Count**"), excel.Markdown("**Exists in the projects**")}}
+
+	typeNames := make([]string, 0, len(r.unresolvedTypes))
+	for typeName := range r.unresolvedTypes {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Slice(typeNames, func(i, j int) bool {
+		if r.unresolvedTypes[typeNames[i]] == r.unresolvedTypes[typeNames[j]] {
+			return typeNames[i] < typeNames[j]
+		}
+		return r.unresolvedTypes[typeNames[i]] > r.unresolvedTypes[typeNames[j]]
+	})
+	for i, typeName := range typeNames {
+		if i == maxUnresolvedTypeRows {
+			result = append(result, []interface{}{fmt.Sprintf("%d more types", len(typeNames)-i), "", ""})
+			break
+		}
+		exists := "no"
+		if r.resolver.Exists(typeName) {
+			// the type exists, but is not imported in the class of the method
+			exists = "yes"
+		}
+		result = append(result, []interface{}{typeName, r.unresolvedTypes[typeName], exists})
+	}
+	return result
+}
+
+func (r *TypeResolutionRater) Name() string {
+	return "Type resolution rate"
+}
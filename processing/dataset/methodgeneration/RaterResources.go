@@ -0,0 +1,40 @@
+package methodgeneration
+
+import (
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/metrics"
+)
+
+// Resources which are required by some raters only. They are loaded on first use and shared by all raters, as they
+// are expensive to load.
+type RaterResources struct {
+	typeResolver *TypeResolver
+	// The loaded word vectors by the path of their file
+	embeddings map[string]*metrics.Embeddings
+}
+
+func (r *RaterResources) TypeResolver() (*TypeResolver, errors.Error) {
+	if r.typeResolver == nil {
+		resolver, err := LoadTypeResolver()
+		if err != nil {
+			return nil, err
+		}
+		r.typeResolver = resolver
+	}
+	return r.typeResolver, nil
+}
+
+func (r *RaterResources) Embeddings(path string) (*metrics.Embeddings, errors.Error) {
+	if embeddings, ok := r.embeddings[path]; ok {
+		return embeddings, nil
+	}
+	embeddings, err := metrics.LoadEmbeddings(path)
+	if err != nil {
+		return nil, err
+	}
+	if r.embeddings == nil {
+		r.embeddings = make(map[string]*metrics.Embeddings)
+	}
+	r.embeddings[path] = embeddings
+	return embeddings, nil
+}
@@ -0,0 +1,99 @@
+package methodgeneration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/common/utils"
+)
+
+// Rates the generated methods files of the final model, its checkpoints and the folds of the cross validation again
+// using the current evaluation configuration. The predictor is not used, so the outputs of the examples are left out.
+// The results are written as new versions next to the previous results, which are kept.
+func (e *Evaluator) Rescore(path string) errors.Error {
+	log.Info("Rescore dataset %s\n", e.Dataset.Name())
+	if err := e.rescoreResult(path, ""); err != nil {
+		return err
+	}
+	checkpoints, err := findCheckpointDirectories(path)
+	if err != nil {
+		return err
+	}
+	for _, checkpoint := range checkpoints {
+		if err := e.rescoreResult(filepath.Join(path, checkpoint), checkpoint); err != nil {
+			return err
+		}
+	}
+	if e.Dataset.CreationOptions.CrossValidation.IsActive() {
+		for i, fold := range e.Dataset.Folds() {
+			evaluator := &Evaluator{
+				Dataset: fold,
+			}
+			if err := evaluator.rescoreResult(FoldPath(path, i), ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Rates the generated methods file in the path and writes the next version of the result. Paths without generated
+// methods file are skipped.
+func (e *Evaluator) rescoreResult(path, checkpoint string) errors.Error {
+	generatedMethodsPath := filepath.Join(path, e.Dataset.Name()+GeneratedMethodsFile)
+	if !utils.FileExists(generatedMethodsPath) {
+		log.Info("Skip %s as there is no generated methods file\n", path)
+		return nil
+	}
+	methods, err := LoadGeneratedMethods(generatedMethodsPath)
+	if err != nil {
+		return err
+	}
+	version := nextResultVersion(path, e.Dataset.Name()+ResultOutputFile)
+	log.Info("Rescore %d methods in %s (version %d)\n", len(methods), path, version)
+	_, err = e.rateAndWriteResult(path, checkpoint, version, methods, false)
+	return err
+}
+
+// Returns the names of the checkpoint directories in the path in ascending order of their step.
+func findCheckpointDirectories(path string) ([]string, errors.Error) {
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Evaluation", "Could not read the checkpoints in %s", path)
+	}
+	results := make([]CheckpointResult, 0)
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), "checkpoint-") {
+			results = append(results, CheckpointResult{Checkpoint: entry.Name()})
+		}
+	}
+	sortCheckpointResults(results)
+	checkpoints := make([]string, len(results))
+	for i, result := range results {
+		checkpoints[i] = result.Checkpoint
+	}
+	return checkpoints, nil
+}
+
+// Returns the lowest version of the result file which does not exist in the path yet. The first version is the
+// result file without version number.
+func nextResultVersion(path, fileName string) int {
+	version := 1
+	for utils.FileExists(filepath.Join(path, versionedFileName(fileName, version))) {
+		version++
+	}
+	return version
+}
+
+// Adds the version to the file name (like methodgeneration_result_v2.xlsx). The first version has no version number.
+func versionedFileName(fileName string, version int) string {
+	if version <= 1 {
+		return fileName
+	}
+	extension := filepath.Ext(fileName)
+	return fmt.Sprintf("%s_v%d%s", strings.TrimSuffix(fileName, extension), version, extension)
+}
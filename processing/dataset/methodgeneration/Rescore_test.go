@@ -0,0 +1,48 @@
+package methodgeneration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/metrics"
+	"returntypes-langserver/common/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionedFileName(t *testing.T) {
+	assert.Equal(t, "methodgeneration_result.xlsx", versionedFileName("methodgeneration_result.xlsx", 1))
+	assert.Equal(t, "methodgeneration_result_v3.xlsx", versionedFileName("methodgeneration_result.xlsx", 3))
+}
+
+func TestRescoreWritesNextVersion(t *testing.T) {
+	// given
+	path := t.TempDir()
+	evaluator := &Evaluator{Dataset: configuration.Dataset{DatasetBase: configuration.DatasetBase{NameRaw: "set"}}}
+	name := evaluator.Dataset.Name()
+	methods := []Method{{
+		Name:                 "get name",
+		ClassName:            "com.example.User",
+		ExpectedDefinition:   metrics.NewSentence("[rsp] string"),
+		GeneratedDefinition:  metrics.NewSentence("[rsp] string"),
+		GeneratedDefinitions: []*metrics.Sentence{metrics.NewSentence("[rsp] string")},
+	}}
+	checkpointPath := filepath.Join(path, "checkpoint-100")
+	assert.NoError(t, os.MkdirAll(checkpointPath, 0777))
+	assert.NoError(t, WriteGeneratedMethods(filepath.Join(path, name+GeneratedMethodsFile), methods))
+	assert.NoError(t, WriteGeneratedMethods(filepath.Join(checkpointPath, name+GeneratedMethodsFile), methods))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(path, name+ResultOutputFile), []byte{}, 0644))
+
+	// when
+	err := evaluator.Rescore(path)
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, utils.FileExists(filepath.Join(path, versionedFileName(name+ResultOutputFile, 2))))
+	assert.True(t, utils.FileExists(filepath.Join(path, versionedFileName(name+ReportOutputFile, 2))))
+	assert.True(t, utils.FileExists(filepath.Join(checkpointPath, name+ResultOutputFile)))
+	assert.Equal(t, 3, nextResultVersion(path, name+ResultOutputFile))
+}
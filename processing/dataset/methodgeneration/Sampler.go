@@ -0,0 +1,198 @@
+package methodgeneration
+
+import (
+	"math"
+	"math/rand"
+	"returntypes-langserver/common/code/java"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/utils"
+	"sort"
+)
+
+const TypeClassDistributionFileName = "methodgeneration_typeClassDistribution.csv"
+
+// Balances the dataset rows by the type class of their return types using the configured sampling options.
+type Sampler struct {
+	Options configuration.DatasetSampling
+	random  *rand.Rand
+}
+
+func NewSampler(options configuration.DatasetSampling) *Sampler {
+	return &Sampler{
+		Options: options,
+		random:  rand.New(rand.NewSource(options.Seed)),
+	}
+}
+
+func (s *Sampler) IsActive() bool {
+	return s.Options.IsActive()
+}
+
+// Returns the sampling weight of a method with the given labels, which is the product of the weights of it's labels.
+// Labels without a configured weight have a weight of 1.
+func (s *Sampler) Weight(labels []string) float64 {
+	weight := 1.0
+	for _, label := range labels {
+		if labelWeight, ok := s.Options.LabelWeights[label]; ok {
+			weight *= labelWeight
+		}
+	}
+	return weight
+}
+
+// Returns the type class of the row. If type classes are defined for the dataset, the return type is already mapped
+// to it's type class, otherwise the return type itself is used.
+func TypeClassOf(row csv.MethodGenerationDatasetRow) string {
+	return java.EraseTypeArguments(row.ReturnType)
+}
+
+// Returns the indices of the rows which are kept. Rows with a weight of 0 are removed and type classes exceeding
+// their maximum or the target count are reduced by weighted sampling without replacement. The indices are in ascending order.
+func (s *Sampler) Undersample(typeClasses []string, weights []float64) []int {
+	indicesOfClass, classes := groupByTypeClass(sequence(len(typeClasses)), typeClasses, weights)
+	kept := make([]int, 0, len(typeClasses))
+	for _, class := range classes {
+		indices := indicesOfClass[class]
+		if limit := s.limitOf(class); limit > 0 && len(indices) > limit {
+			indices = s.sampleWithoutReplacement(indices, weights, limit)
+		}
+		kept = append(kept, indices...)
+	}
+	sort.Ints(kept)
+	return kept
+}
+
+// Duplicates rows of type classes below the target count by weighted sampling with replacement. As the target count
+// refers to the whole dataset, the target for the given indices is the share defined by the proportion. Returns the
+// given indices in their order where each duplicate follows it's original index.
+func (s *Sampler) Oversample(indices []int, typeClasses []string, weights []float64, proportion configuration.DatasetProportion) []int {
+	if s.Options.TargetPerTypeClass <= 0 {
+		return indices
+	}
+	indicesOfClass, classes := groupByTypeClass(indices, typeClasses, weights)
+	duplicates := make(map[int]int)
+	for _, class := range classes {
+		target, _ := utils.FitProportions(proportion.Training, proportion.Evaluation, s.targetOf(class))
+		if missing := target - len(indicesOfClass[class]); missing > 0 {
+			for _, index := range s.sampleWithReplacement(indicesOfClass[class], weights, missing) {
+				duplicates[index]++
+			}
+		}
+	}
+	result := make([]int, 0, len(indices))
+	for _, index := range indices {
+		result = append(result, index)
+		for i := 0; i < duplicates[index]; i++ {
+			result = append(result, index)
+		}
+	}
+	return result
+}
+
+// Returns the maximum number of rows of the type class after undersampling or 0 if there is no limit.
+func (s *Sampler) limitOf(class string) int {
+	if target := s.targetOf(class); target > 0 {
+		return target
+	}
+	return s.Options.MaxOf(class)
+}
+
+// Returns the target number of rows of the type class, which is limited by the maximum of the type class.
+func (s *Sampler) targetOf(class string) int {
+	target := s.Options.TargetPerTypeClass
+	if max := s.Options.MaxOf(class); max > 0 && max < target {
+		return max
+	}
+	return target
+}
+
+// Chooses count indices where the probability of each index is proportional to it's weight (using the algorithm of
+// Efraimidis and Spirakis). The chosen indices are in ascending order.
+func (s *Sampler) sampleWithoutReplacement(indices []int, weights []float64, count int) []int {
+	keys := make(map[int]float64, len(indices))
+	for _, index := range indices {
+		keys[index] = math.Pow(s.random.Float64(), 1/weights[index])
+	}
+	chosen := make([]int, len(indices))
+	copy(chosen, indices)
+	sort.SliceStable(chosen, func(i, j int) bool {
+		return keys[chosen[i]] > keys[chosen[j]]
+	})
+	chosen = chosen[:count]
+	sort.Ints(chosen)
+	return chosen
+}
+
+// Chooses count indices where the probability of each index is proportional to it's weight. Indices may be chosen multiple times.
+func (s *Sampler) sampleWithReplacement(indices []int, weights []float64, count int) []int {
+	cumulativeWeights := make([]float64, len(indices))
+	sum := 0.0
+	for i, index := range indices {
+		sum += weights[index]
+		cumulativeWeights[i] = sum
+	}
+	chosen := make([]int, count)
+	for i := range chosen {
+		position := sort.SearchFloat64s(cumulativeWeights, s.random.Float64()*sum)
+		if position >= len(indices) {
+			position = len(indices) - 1
+		}
+		chosen[i] = indices[position]
+	}
+	return chosen
+}
+
+// Groups the indices by their type class. Indices with a weight of 0 or less are left out. Returns the type classes
+// in order of their first occurence.
+func groupByTypeClass(indices []int, typeClasses []string, weights []float64) (map[string][]int, []string) {
+	indicesOfClass := make(map[string][]int)
+	classes := make([]string, 0)
+	for _, index := range indices {
+		if weights[index] <= 0 {
+			continue
+		}
+		class := typeClasses[index]
+		if _, ok := indicesOfClass[class]; !ok {
+			classes = append(classes, class)
+		}
+		indicesOfClass[class] = append(indicesOfClass[class], index)
+	}
+	return indicesOfClass, classes
+}
+
+// Counts the rows of each type class in the different stages of the dataset creation.
+type typeClassDistribution struct {
+	counts  map[string]*csv.TypeClassDistribution
+	classes []string
+}
+
+func newTypeClassDistribution() *typeClassDistribution {
+	return &typeClassDistribution{
+		counts: make(map[string]*csv.TypeClassDistribution),
+	}
+}
+
+// Increments the counter selected by the function for the type class of each index.
+func (d *typeClassDistribution) count(indices []int, typeClasses []string, counter func(*csv.TypeClassDistribution) *int) {
+	for _, index := range indices {
+		class := typeClasses[index]
+		if _, ok := d.counts[class]; !ok {
+			d.counts[class] = &csv.TypeClassDistribution{TypeClass: class}
+			d.classes = append(d.classes, class)
+		}
+		*counter(d.counts[class])++
+	}
+}
+
+// Returns the counts of each type class sorted by the number of methods before sampling in descending order.
+func (d *typeClassDistribution) records() []csv.TypeClassDistribution {
+	records := make([]csv.TypeClassDistribution, len(d.classes))
+	for i, class := range d.classes {
+		records[i] = *d.counts[class]
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Methods > records[j].Methods
+	})
+	return records
+}
@@ -0,0 +1,57 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndersampleByTypeClass(t *testing.T) {
+	// given
+	sampler := NewSampler(configuration.DatasetSampling{
+		MaxPerTypeClass: map[string]int{"*": 2, "String": 3},
+		LabelWeights:    map[string]float64{"setter": 0},
+		Seed:            1,
+	})
+	typeClasses := []string{"void", "void", "void", "void", "String", "String", "boolean"}
+	labels := [][]string{{"setter"}, nil, nil, nil, {"getter"}, nil, nil}
+	weights := make([]float64, len(labels))
+	for i := range labels {
+		weights[i] = sampler.Weight(labels[i])
+	}
+
+	// when
+	indices := sampler.Undersample(typeClasses, weights)
+
+	// then
+	counts := make(map[string]int)
+	for _, index := range indices {
+		assert.NotEqual(t, 0, index)
+		counts[typeClasses[index]]++
+	}
+	assert.Equal(t, map[string]int{"void": 2, "String": 2, "boolean": 1}, counts)
+}
+
+func TestOversampleTrainingSet(t *testing.T) {
+	// given
+	sampler := NewSampler(configuration.DatasetSampling{
+		TargetPerTypeClass: 4,
+		Seed:               1,
+	})
+	typeClasses := []string{"void", "void", "void", "void", "int"}
+	weights := []float64{1, 1, 1, 1, 1}
+	proportion := configuration.DatasetProportion{Training: 1, Evaluation: 0}
+
+	// when
+	indices := sampler.Oversample([]int{3, 4, 0}, typeClasses, weights, proportion)
+
+	// then
+	counts := make(map[string]int)
+	for _, index := range indices {
+		counts[typeClasses[index]]++
+	}
+	assert.Equal(t, map[string]int{"void": 4, "int": 4}, counts)
+	assert.Equal(t, 3, indices[0])
+}
@@ -0,0 +1,313 @@
+package methodgeneration
+
+import (
+	"math/rand"
+	"path/filepath"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/common/utils"
+	"returntypes-langserver/processing/projects"
+)
+
+// The maximum number of operations (rows * groups) for which the best group split is searched exactly. For larger
+// inputs, the groups are assigned greedily.
+const maxExactGroupSplitOperations = 100000000
+
+// Splits the dataset rows into a training and an evaluation set using the configured split strategy.
+type Splitter struct {
+	Options    configuration.DatasetSplit
+	Proportion configuration.DatasetProportion
+	projects   []projects.Project
+	// The number of methods for which no project could be determined
+	methodsWithoutProject int
+}
+
+func NewSplitter(options configuration.DatasetSplit, proportion configuration.DatasetProportion, projectList []projects.Project) *Splitter {
+	return &Splitter{
+		Options:    options,
+		Proportion: proportion,
+		projects:   projectList,
+	}
+}
+
+// Returns the key of the group the method belongs to. Methods with the same key are put into the same set.
+// Returns an empty string if the strategy does not split by groups.
+func (s *Splitter) GetGroupKey(method *csv.Method) string {
+	switch s.Options.GetStrategy() {
+	case configuration.ProjectSplit:
+		if method.Project != "" {
+			return method.Project
+		}
+		// Methods extracted by older versions have no project, which can only be found for absolute file paths
+		for _, project := range s.projects {
+			if filepath.IsAbs(method.FilePath) && project.ContainsFile(method.FilePath) {
+				return project.Name()
+			}
+		}
+		// If the project is unknown, at least methods of the same package stay together
+		s.methodsWithoutProject++
+		return filepath.Dir(method.FilePath)
+	case configuration.FileSplit:
+		return method.FilePath
+	case configuration.PackageSplit:
+		return filepath.Dir(method.FilePath)
+	}
+	return ""
+}
+
+// Returns the key of a finer group the method belongs to, which is used if all methods are in the same group returned by
+// GetGroupKey. Methods of one project are then splitted by package and methods of one package by file. Returns an empty
+// string if there is no finer group.
+func (s *Splitter) GetFallbackGroupKey(method *csv.Method) string {
+	switch s.Options.GetStrategy() {
+	case configuration.ProjectSplit:
+		return filepath.Dir(method.FilePath)
+	case configuration.PackageSplit:
+		return method.FilePath
+	}
+	return ""
+}
+
+// Splits the rows into a training and an evaluation set. groupKeys and fallbackGroupKeys contain the group keys for each
+// row as returned by GetGroupKey and GetFallbackGroupKey.
+func (s *Splitter) Split(rows []csv.MethodGenerationDatasetRow, groupKeys, fallbackGroupKeys []string) (trainingSet, evaluationSet []csv.MethodGenerationDatasetRow) {
+	trainingIndices, evaluationIndices := s.SplitIndices(len(rows), groupKeys, fallbackGroupKeys)
+	return selectRows(rows, trainingIndices), selectRows(rows, evaluationIndices)
+}
+
+// Splits the indices of rowCount rows into the indices of the training and the evaluation set.
+func (s *Splitter) SplitIndices(rowCount int, groupKeys, fallbackGroupKeys []string) (trainingIndices, evaluationIndices []int) {
+	trainingSetSize, _ := utils.FitProportions(s.Proportion.Training, s.Proportion.Evaluation, rowCount)
+	indices := sequence(rowCount)
+	switch s.Options.GetStrategy() {
+	case configuration.RandomSplit:
+		s.random().Shuffle(len(indices), func(i, j int) {
+			indices[i], indices[j] = indices[j], indices[i]
+		})
+		return indices[:trainingSetSize], indices[trainingSetSize:]
+	case configuration.ProjectSplit, configuration.FileSplit, configuration.PackageSplit:
+		s.reportMethodsWithoutProject()
+		if rowCount > 1 && !hasMultipleGroups(groupKeys) {
+			if hasMultipleGroups(fallbackGroupKeys) {
+				log.ReportProblem("All methods belong to the same group of the %s split strategy, so they are splitted by their %s instead.\n",
+					s.Options.GetStrategy(), s.fallbackGroupName())
+				groupKeys = fallbackGroupKeys
+			} else {
+				log.ReportProblem("All methods belong to the same group of the %s split strategy, so they are splitted sequentially instead.\n", s.Options.GetStrategy())
+				return indices[:trainingSetSize], indices[trainingSetSize:]
+			}
+		}
+		trainingIndices, evaluationIndices = s.splitByGroups(groupKeys, trainingSetSize)
+		if utils.AbsInt(len(trainingIndices)-trainingSetSize) > rowCount/10 {
+			log.ReportProblem("The training set contains %d instead of %d methods, as the groups of the %s split strategy cannot be divided more evenly.\n",
+				len(trainingIndices), trainingSetSize, s.Options.GetStrategy())
+		}
+		return trainingIndices, evaluationIndices
+	default:
+		return indices[:trainingSetSize], indices[trainingSetSize:]
+	}
+}
+
+// Reports the methods for which GetGroupKey could not determine a project since the last split and resets their count.
+func (s *Splitter) reportMethodsWithoutProject() {
+	if s.methodsWithoutProject > 0 {
+		log.ReportProblem("The project of %d methods is unknown, so they are splitted by their package. Extract the projects again to split them by project.\n", s.methodsWithoutProject)
+	}
+	s.methodsWithoutProject = 0
+}
+
+// Returns the name of the groups returned by GetFallbackGroupKey.
+func (s *Splitter) fallbackGroupName() string {
+	if s.Options.GetStrategy() == configuration.ProjectSplit {
+		return "package"
+	}
+	return "file"
+}
+
+// Returns true if the group keys contain at least two different keys.
+func hasMultipleGroups(groupKeys []string) bool {
+	for _, key := range groupKeys {
+		if key != groupKeys[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// Assigns whole groups to the training set, so that the size of the training set is as close as possible to the expected
+// training set size. All other groups are put into the evaluation set. The order of the rows is kept in each set.
+func (s *Splitter) splitByGroups(groupKeys []string, trainingSetSize int) (trainingIndices, evaluationIndices []int) {
+	groups, groupSizes := s.shuffledGroups(groupKeys)
+	var isTrainingGroup map[string]bool
+	if len(groupKeys)*len(groups) <= maxExactGroupSplitOperations {
+		isTrainingGroup = selectClosestGroups(groups, groupSizes, trainingSetSize, len(groupKeys))
+	} else {
+		isTrainingGroup = selectGroupsGreedily(groups, groupSizes, trainingSetSize)
+	}
+
+	trainingIndices = make([]int, 0, trainingSetSize)
+	evaluationIndices = make([]int, 0, len(groupKeys)-trainingSetSize)
+	for i, key := range groupKeys {
+		if isTrainingGroup[key] {
+			trainingIndices = append(trainingIndices, i)
+		} else {
+			evaluationIndices = append(evaluationIndices, i)
+		}
+	}
+	return
+}
+
+// Selects the groups whose sizes sum up as close as possible to the target size. If there are at least two groups,
+// at least one group is selected and at least one group is not selected (as long as the target size is neither 0 nor
+// the total size), even if each group is larger than the target size. Groups earlier in the list are preferred.
+func selectClosestGroups(groups []string, groupSizes map[string]int, targetSize, totalSize int) map[string]bool {
+	isSelected := make(map[string]bool)
+	if targetSize <= 0 {
+		return isSelected
+	} else if targetSize >= totalSize {
+		for _, group := range groups {
+			isSelected[group] = true
+		}
+		return isSelected
+	}
+
+	// The (1-based) index of the group with which a size was reached first or 0 if the size is not reachable
+	reachedBy := make([]int, totalSize+1)
+	for i, group := range groups {
+		groupSize := groupSizes[group]
+		for size := totalSize; size >= groupSize; size-- {
+			if reachedBy[size] == 0 && (size == groupSize || reachedBy[size-groupSize] != 0) {
+				reachedBy[size] = i + 1
+			}
+		}
+	}
+
+	bestSize := 0
+	for size := 1; size < totalSize; size++ {
+		if reachedBy[size] != 0 && (bestSize == 0 || utils.AbsInt(size-targetSize) < utils.AbsInt(bestSize-targetSize)) {
+			bestSize = size
+		}
+	}
+	for size := bestSize; size > 0; {
+		group := groups[reachedBy[size]-1]
+		isSelected[group] = true
+		size -= groupSizes[group]
+	}
+	return isSelected
+}
+
+// Selects the groups in the given order as long as they fit into the target size. If no group fits, the smallest group
+// is selected for a positive target size.
+func selectGroupsGreedily(groups []string, groupSizes map[string]int, targetSize int) map[string]bool {
+	isSelected := make(map[string]bool)
+	size := 0
+	smallestGroup := 0
+	for i, group := range groups {
+		if size+groupSizes[group] <= targetSize {
+			isSelected[group] = true
+			size += groupSizes[group]
+		}
+		if groupSizes[group] < groupSizes[groups[smallestGroup]] {
+			smallestGroup = i
+		}
+	}
+	if size == 0 && targetSize > 0 && len(groups) > 1 {
+		isSelected[groups[smallestGroup]] = true
+	}
+	return isSelected
+}
+
+// Returns the distinct group keys in random order and the number of rows of each group.
+func (s *Splitter) shuffledGroups(groupKeys []string) ([]string, map[string]int) {
+	groupSizes := make(map[string]int)
+	groups := make([]string, 0)
+	for _, key := range groupKeys {
+		if _, ok := groupSizes[key]; !ok {
+			groups = append(groups, key)
+		}
+		groupSizes[key]++
+	}
+	s.random().Shuffle(len(groups), func(i, j int) {
+		groups[i], groups[j] = groups[j], groups[i]
+	})
+	return groups, groupSizes
+}
+
+// Splits the rows into the given number of folds of nearly equal size. Groups are not splitted across multiple folds,
+// so each fold contains whole groups for the group based strategies.
+func (s *Splitter) SplitIntoFolds(rows []csv.MethodGenerationDatasetRow, groupKeys []string, foldCount int) [][]csv.MethodGenerationDatasetRow {
+	foldIndices := s.SplitIndicesIntoFolds(len(rows), groupKeys, foldCount)
+	folds := make([][]csv.MethodGenerationDatasetRow, foldCount)
+	for i := range foldIndices {
+		folds[i] = selectRows(rows, foldIndices[i])
+	}
+	return folds
+}
+
+// Splits the indices of rowCount rows into the given number of folds like SplitIntoFolds.
+func (s *Splitter) SplitIndicesIntoFolds(rowCount int, groupKeys []string, foldCount int) [][]int {
+	folds := make([][]int, foldCount)
+	switch s.Options.GetStrategy() {
+	case configuration.ProjectSplit, configuration.FileSplit, configuration.PackageSplit:
+		s.reportMethodsWithoutProject()
+		foldOfGroup := s.assignGroupsToFolds(groupKeys, foldCount)
+		for i, key := range groupKeys {
+			fold := foldOfGroup[key]
+			folds[fold] = append(folds[fold], i)
+		}
+	default:
+		indices := sequence(rowCount)
+		if s.Options.GetStrategy() == configuration.RandomSplit {
+			s.random().Shuffle(len(indices), func(i, j int) {
+				indices[i], indices[j] = indices[j], indices[i]
+			})
+		}
+		for position, index := range indices {
+			fold := position * foldCount / rowCount
+			folds[fold] = append(folds[fold], index)
+		}
+	}
+	return folds
+}
+
+// Assigns the groups in random order each to the currently smallest fold. Returns the fold index for each group key.
+func (s *Splitter) assignGroupsToFolds(groupKeys []string, foldCount int) map[string]int {
+	groups, groupSizes := s.shuffledGroups(groupKeys)
+
+	foldSizes := make([]int, foldCount)
+	foldOfGroup := make(map[string]int)
+	for _, group := range groups {
+		smallestFold := 0
+		for fold := range foldSizes {
+			if foldSizes[fold] < foldSizes[smallestFold] {
+				smallestFold = fold
+			}
+		}
+		foldOfGroup[group] = smallestFold
+		foldSizes[smallestFold] += groupSizes[group]
+	}
+	return foldOfGroup
+}
+
+func (s *Splitter) random() *rand.Rand {
+	return rand.New(rand.NewSource(s.Options.Seed))
+}
+
+// Returns the rows at the given indices in the order of the indices.
+func selectRows(rows []csv.MethodGenerationDatasetRow, indices []int) []csv.MethodGenerationDatasetRow {
+	selected := make([]csv.MethodGenerationDatasetRow, len(indices))
+	for i, index := range indices {
+		selected[i] = rows[index]
+	}
+	return selected
+}
+
+// Returns the numbers from 0 to n-1.
+func sequence(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
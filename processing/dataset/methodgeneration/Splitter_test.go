@@ -0,0 +1,219 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitByFile(t *testing.T) {
+	// given
+	splitter := NewSplitter(configuration.DatasetSplit{
+		Strategy: configuration.FileSplit,
+		Seed:     42,
+	}, configuration.DatasetProportion{Training: 1, Evaluation: 1}, nil)
+	methods := []csv.Method{
+		{MethodName: "a", FilePath: "A.java"},
+		{MethodName: "b", FilePath: "B.java"},
+		{MethodName: "c", FilePath: "A.java"},
+		{MethodName: "d", FilePath: "B.java"},
+	}
+	rows := make([]csv.MethodGenerationDatasetRow, len(methods))
+	groupKeys := make([]string, len(methods))
+	for i := range methods {
+		rows[i] = csv.MethodGenerationDatasetRow{MethodName: methods[i].MethodName}
+		groupKeys[i] = splitter.GetGroupKey(&methods[i])
+	}
+
+	// when
+	trainingSet, evaluationSet := splitter.Split(rows, groupKeys, nil)
+
+	// then
+	assert.Len(t, trainingSet, 2)
+	assert.Len(t, evaluationSet, 2)
+	if trainingSet[0].MethodName == "a" {
+		assert.Equal(t, "c", trainingSet[1].MethodName)
+	} else {
+		assert.Equal(t, "b", trainingSet[0].MethodName)
+		assert.Equal(t, "d", trainingSet[1].MethodName)
+	}
+}
+
+func TestSplitByProjectWithRelativeFilePaths(t *testing.T) {
+	// given
+	splitter := NewSplitter(configuration.DatasetSplit{
+		Strategy: configuration.ProjectSplit,
+		Seed:     42,
+	}, configuration.DatasetProportion{Training: 1, Evaluation: 1}, nil)
+	methods := []csv.Method{
+		{MethodName: "a", FilePath: "src/main/java/com/example/User.java", Project: "first"},
+		{MethodName: "b", FilePath: "src/main/java/com/example/User.java", Project: "second"},
+		{MethodName: "c", FilePath: "src/main/java/com/example/util/Strings.java", Project: "first"},
+		{MethodName: "d", FilePath: "src/test/java/com/example/UserTest.java", Project: "second"},
+	}
+	rows := make([]csv.MethodGenerationDatasetRow, len(methods))
+	groupKeys := make([]string, len(methods))
+	for i := range methods {
+		rows[i] = csv.MethodGenerationDatasetRow{MethodName: methods[i].MethodName}
+		groupKeys[i] = splitter.GetGroupKey(&methods[i])
+	}
+
+	// when
+	trainingSet, evaluationSet := splitter.Split(rows, groupKeys, nil)
+
+	// then
+	assert.Equal(t, []string{"first", "second", "first", "second"}, groupKeys)
+	assert.Len(t, trainingSet, 2)
+	assert.Len(t, evaluationSet, 2)
+	if trainingSet[0].MethodName == "a" {
+		assert.Equal(t, "c", trainingSet[1].MethodName)
+	} else {
+		assert.Equal(t, "b", trainingSet[0].MethodName)
+		assert.Equal(t, "d", trainingSet[1].MethodName)
+	}
+}
+
+func TestSplitByGroupsKeepsProportionWithUnevenGroups(t *testing.T) {
+	// given
+	splitter := NewSplitter(configuration.DatasetSplit{
+		Strategy: configuration.ProjectSplit,
+		Seed:     42,
+	}, configuration.DatasetProportion{Training: 0.8, Evaluation: 0.2}, nil)
+	groupKeys := make([]string, 100)
+	for i := range groupKeys {
+		if i < 90 {
+			groupKeys[i] = "large"
+		} else {
+			groupKeys[i] = "small"
+		}
+	}
+
+	// when
+	trainingIndices, evaluationIndices := splitter.SplitIndices(len(groupKeys), groupKeys, nil)
+
+	// then
+	assert.Len(t, trainingIndices, 90)
+	assert.Len(t, evaluationIndices, 10)
+	assert.Equal(t, 90, evaluationIndices[0])
+}
+
+func TestSplitByGroupsPutsOneGroupIntoTrainingSetIfAllGroupsAreLarger(t *testing.T) {
+	// given
+	splitter := NewSplitter(configuration.DatasetSplit{
+		Strategy: configuration.ProjectSplit,
+		Seed:     42,
+	}, configuration.DatasetProportion{Training: 0.1, Evaluation: 0.9}, nil)
+	groupKeys := make([]string, 100)
+	for i := range groupKeys {
+		if i < 50 {
+			groupKeys[i] = "first"
+		} else {
+			groupKeys[i] = "second"
+		}
+	}
+
+	// when
+	trainingIndices, evaluationIndices := splitter.SplitIndices(len(groupKeys), groupKeys, nil)
+
+	// then
+	assert.Len(t, trainingIndices, 50)
+	assert.Len(t, evaluationIndices, 50)
+}
+
+func TestSplitByProjectFallsBackToPackagesForOneProject(t *testing.T) {
+	// given
+	splitter := NewSplitter(configuration.DatasetSplit{
+		Strategy: configuration.ProjectSplit,
+		Seed:     42,
+	}, configuration.DatasetProportion{Training: 1, Evaluation: 1}, nil)
+	methods := []csv.Method{
+		{MethodName: "a", FilePath: "src/com/example/A.java", Project: "project"},
+		{MethodName: "b", FilePath: "src/com/example/util/B.java", Project: "project"},
+		{MethodName: "c", FilePath: "src/com/example/C.java", Project: "project"},
+		{MethodName: "d", FilePath: "src/com/example/util/D.java", Project: "project"},
+	}
+	rows := make([]csv.MethodGenerationDatasetRow, len(methods))
+	groupKeys := make([]string, len(methods))
+	fallbackGroupKeys := make([]string, len(methods))
+	for i := range methods {
+		rows[i] = csv.MethodGenerationDatasetRow{MethodName: methods[i].MethodName}
+		groupKeys[i] = splitter.GetGroupKey(&methods[i])
+		fallbackGroupKeys[i] = splitter.GetFallbackGroupKey(&methods[i])
+	}
+
+	// when
+	trainingSet, evaluationSet := splitter.Split(rows, groupKeys, fallbackGroupKeys)
+
+	// then
+	assert.Len(t, trainingSet, 2)
+	assert.Len(t, evaluationSet, 2)
+	if trainingSet[0].MethodName == "a" {
+		assert.Equal(t, "c", trainingSet[1].MethodName)
+	} else {
+		assert.Equal(t, "b", trainingSet[0].MethodName)
+		assert.Equal(t, "d", trainingSet[1].MethodName)
+	}
+}
+
+func TestMethodsWithoutProjectAreCountedPerSplit(t *testing.T) {
+	// given
+	splitter := NewSplitter(configuration.DatasetSplit{Strategy: configuration.ProjectSplit}, configuration.DatasetProportion{Training: 1, Evaluation: 1}, nil)
+	method := csv.Method{FilePath: "src/A.java"}
+	splitter.GetGroupKey(&method)
+	splitter.GetGroupKey(&method)
+
+	// when
+	splitter.SplitIndices(2, []string{"a", "b"}, nil)
+	countAfterSplit := splitter.methodsWithoutProject
+	splitter.GetGroupKey(&method)
+
+	// then
+	assert.Equal(t, 0, countAfterSplit)
+	assert.Equal(t, 1, splitter.methodsWithoutProject)
+}
+
+func TestRandomSplitIsReproducible(t *testing.T) {
+	// given
+	options := configuration.DatasetSplit{Strategy: configuration.RandomSplit, Seed: 7}
+	proportion := configuration.DatasetProportion{Training: 3, Evaluation: 1}
+	rows := make([]csv.MethodGenerationDatasetRow, 20)
+	for i := range rows {
+		rows[i].MethodName = string(rune('a' + i))
+	}
+
+	// when
+	trainingSetA, evaluationSetA := NewSplitter(options, proportion, nil).Split(rows, nil, nil)
+	trainingSetB, evaluationSetB := NewSplitter(options, proportion, nil).Split(rows, nil, nil)
+
+	// then
+	assert.Len(t, trainingSetA, 15)
+	assert.Equal(t, trainingSetA, trainingSetB)
+	assert.Equal(t, evaluationSetA, evaluationSetB)
+}
+
+func TestSplitIntoFoldsKeepsGroupsTogether(t *testing.T) {
+	// given
+	splitter := NewSplitter(configuration.DatasetSplit{Strategy: configuration.PackageSplit}, configuration.DatasetProportion{}, nil)
+	rows := make([]csv.MethodGenerationDatasetRow, 9)
+	groupKeys := make([]string, 9)
+	for i := range rows {
+		rows[i].MethodName = string(rune('a' + i))
+		groupKeys[i] = string(rune('a' + i%3))
+	}
+
+	// when
+	folds := splitter.SplitIntoFolds(rows, groupKeys, 3)
+
+	// then
+	assert.Len(t, folds, 3)
+	for _, fold := range folds {
+		assert.Len(t, fold, 3)
+		group := (fold[0].MethodName[0] - 'a') % 3
+		for _, row := range fold {
+			assert.Equal(t, group, (row.MethodName[0]-'a')%3)
+		}
+	}
+}
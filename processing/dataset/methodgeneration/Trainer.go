@@ -12,6 +12,9 @@ import (
 	"returntypes-langserver/services/predictor"
 )
 
+// The suffix of the file containing the configuration of a trained dataset
+const TrainingConfigFileSuffix = "_config.json"
+
 type Trainer struct {
 	Dataset configuration.Dataset
 }
@@ -23,12 +26,34 @@ func NewTrainer(dataset configuration.Dataset) base.Trainer {
 }
 
 func (t *Trainer) Train(path string) errors.Error {
+	if err := t.train(path); err != nil {
+		return err
+	}
+	return t.trainFolds(path)
+}
+
+// Trains a model for each fold of the cross validation (if active) using the fold-suffixed dataset identifiers.
+func (t *Trainer) trainFolds(path string) errors.Error {
+	for i, fold := range t.Dataset.Folds() {
+		log.Info("[Method generation] Train fold %d of dataset '%s'\n", i+1, t.Dataset.Name())
+		if err := NewTrainer(fold).Train(FoldPath(path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Trainer) train(path string) errors.Error {
 	continueTraining := utils.ContainsString(configuration.ContinueTraining(), t.Dataset.Name())
 	if exists, err := predictor.OnDataset(t.Dataset).ModelExists(predictor.MethodGenerator); err != nil {
 		return err
 	} else if exists && !continueTraining {
-		// Skip because the model is already trained
+		// Skip because the model is already trained. The configuration is saved if it is missing, as it is the output
+		// of the training step.
 		log.Info("[Method generation] Skip training of dataset '%s' because it is already trained.\n", t.Dataset.Name())
+		if !utils.FileExists(filepath.Join(path, t.Dataset.Name()+TrainingConfigFileSuffix)) {
+			return t.saveDataset(path)
+		}
 		return nil
 	} else if err := t.saveDataset(path); err != nil {
 		return err
@@ -51,12 +76,24 @@ func (t *Trainer) Train(path string) errors.Error {
 	return predictor.OnDataset(t.Dataset).TrainMethods(methods, continueTraining)
 }
 
+// Returns the files written by the training of the dataset and it's folds in the path.
+func TrainingOutputs(path string, dataset configuration.Dataset) []string {
+	outputs := []string{filepath.Join(path, dataset.Name()+TrainingConfigFileSuffix)}
+	for i, fold := range dataset.Folds() {
+		outputs = append(outputs, TrainingOutputs(FoldPath(path, i), fold)...)
+	}
+	return outputs
+}
+
 var ErrCouldNotSaveConfig = errors.ErrorId("Training", "Could not save dataset configuration")
 
 func (t *Trainer) saveDataset(path string) errors.Error {
-	file := utils.OpenFileLazy(filepath.Join(path, t.Dataset.Name()+"_config.json"))
+	file := utils.OpenFileLazy(filepath.Join(path, t.Dataset.Name()+TrainingConfigFileSuffix))
 	defer file.Close()
-	contents, err := json.Marshal(t.Dataset)
+	// Save the used split strategy explicitly, so the split can be reproduced even if the default strategy changes
+	dataset := t.Dataset
+	dataset.CreationOptions.Split.Strategy = dataset.CreationOptions.Split.GetStrategy()
+	contents, err := json.Marshal(dataset)
 	if err != nil {
 		return ErrCouldNotSaveConfig.Wrap(err)
 	}
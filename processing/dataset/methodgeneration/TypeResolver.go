@@ -0,0 +1,242 @@
+package methodgeneration
+
+import (
+	"strings"
+
+	"returntypes-langserver/common/code/java"
+	"returntypes-langserver/common/code/packagetree"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+)
+
+var primitiveTypes = []string{"boolean", "byte", "char", "double", "float", "int", "long", "short", "void"}
+
+// Resolves generated type names against the classes of the projects and the default libraries. As the generated type
+// names are formatted as sentences (like "array list"), the classes are looked up case insensitive.
+type TypeResolver struct {
+	tree *packagetree.Tree
+	// The qualified class names by the lower case of their unqualified names
+	classNames map[string][]string
+}
+
+func NewTypeResolver(classes []csv.Class) *TypeResolver {
+	tree := packagetree.New()
+	java.FillPackageTreeByCsvClassNodes(&tree, classes)
+	resolver := &TypeResolver{
+		tree:       &tree,
+		classNames: make(map[string][]string),
+	}
+	for _, class := range classes {
+		key := typeNameKey(unqualifiedName(class.ClassName))
+		resolver.classNames[key] = append(resolver.classNames[key], class.ClassName)
+	}
+	return resolver
+}
+
+// Loads the class hierarchy of the projects and the classes of the default libraries.
+func LoadTypeResolver() (*TypeResolver, errors.Error) {
+	classes, err := csv.NewFileReader(configuration.ClassHierarchyOutputPath()).ReadClassRecords()
+	if err != nil {
+		return nil, err
+	}
+	for _, defaultLibrary := range configuration.DefaultLibraries() {
+		defaultClasses, err := csv.NewFileReader(defaultLibrary).ReadClassRecords()
+		if err != nil {
+			return nil, err
+		}
+		classes = append(classes, defaultClasses...)
+	}
+	return NewTypeResolver(classes), nil
+}
+
+// Creates the element in which the types of a method are resolved. It consists of the method's class inside of a
+// code file, which imports the classes of the context types.
+func (r *TypeResolver) Context(className string, contextTypes []string) java.JavaElement {
+	packageName, unqualifiedClassName := "", className
+	if index := strings.LastIndex(className, "."); index >= 0 {
+		packageName, unqualifiedClassName = className[:index], className[index+1:]
+	}
+	var existing *java.Class
+	if className != "" {
+		selector := r.tree.Select(className)
+		existing, _ = selector.Get().(*java.Class)
+	}
+	if existing != nil {
+		if codeFile := java.FindCodeFile(existing); codeFile != nil && codeFile.PackageName != "" {
+			// The class may be nested inside of another class
+			packageName = codeFile.PackageName
+			unqualifiedClassName = strings.TrimPrefix(className, packageName+".")
+		}
+	}
+
+	codeFile := &java.CodeFile{
+		PackageName: packageName,
+		Imports:     r.importsFor(contextTypes),
+	}
+	if unqualifiedClassName == "" {
+		return codeFile
+	}
+	class := &java.Class{
+		ClassName: unqualifiedClassName,
+		Modifiers: []string{"public"},
+	}
+	if existing != nil {
+		class.ExtendsImplements = existing.ExtendsImplements
+	}
+	codeFile.AddChild(class, packagetree.SelectionOptions{})
+	return class
+}
+
+// Returns an import for each class matching one of the context types.
+func (r *TypeResolver) importsFor(contextTypes []string) []java.Import {
+	imports := make([]java.Import, 0, len(contextTypes))
+	for _, contextType := range contextTypes {
+		if strings.Contains(contextType, ".") {
+			imports = append(imports, java.Import{ImportPath: contextType})
+			continue
+		}
+		for _, className := range r.classNames[typeNameKey(contextType)] {
+			if unqualifiedName(className) == contextType {
+				imports = append(imports, java.Import{ImportPath: className})
+			}
+		}
+	}
+	return imports
+}
+
+// Resolves the generated type name inside of the context. Returns the canonical name if the type is resolved and
+// otherwise the type name as it would be written in java. Type arguments (like "list < string >") are resolved too.
+func (r *TypeResolver) Resolve(typeName string, context java.JavaElement) (string, bool) {
+	if index := strings.Index(typeName, java.TypeArgumentsStart); index >= 0 {
+		return r.resolveGenericType(typeName[:index], typeArgumentsOf(typeName[index:]), context)
+	}
+
+	key := typeNameKey(typeName)
+	if key == "" {
+		return typeName, false
+	} else if isPrimitiveTypeName(typeName) {
+		return java.ResolveFrom(&java.Type{TypeName: key}, context, r.tree)
+	}
+
+	candidates := r.candidateNames(key)
+	for _, candidate := range candidates {
+		if resolvedTypeName, isResolved := java.ResolveFrom(&java.Type{TypeName: candidate}, context, r.tree); isResolved {
+			return resolvedTypeName, true
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0], false
+	}
+	return ConcatByUpperCamelCase(strings.Fields(typeName)), false
+}
+
+// Resolves the type and it's type arguments. The type is only resolved if all of it's type arguments are resolved.
+func (r *TypeResolver) resolveGenericType(typeName string, typeArguments []string, context java.JavaElement) (string, bool) {
+	resolvedTypeName, isResolved := r.Resolve(typeName, context)
+	if len(typeArguments) == 0 {
+		return resolvedTypeName, isResolved
+	}
+	resolvedArguments := make([]string, len(typeArguments))
+	for i, typeArgument := range typeArguments {
+		wildcard, boundTypeName := splitWildcard(typeArgument)
+		if boundTypeName == "" {
+			resolvedArguments[i] = wildcard
+			continue
+		}
+		resolvedArgument, isArgumentResolved := r.Resolve(boundTypeName, context)
+		if wildcard != "" {
+			resolvedArgument = wildcard + " " + resolvedArgument
+		}
+		resolvedArguments[i] = resolvedArgument
+		isResolved = isResolved && isArgumentResolved
+	}
+	return resolvedTypeName + java.TypeArgumentsStart + strings.Join(resolvedArguments, java.TypeArgumentsSeparator) + java.TypeArgumentsEnd, isResolved
+}
+
+// Returns true if classes with the type name and the names of it's type arguments exist, regardless of whether they
+// are visible in the context of a method.
+func (r *TypeResolver) Exists(typeName string) bool {
+	for _, part := range strings.FieldsFunc(typeName, isTypeArgumentDelimiter) {
+		_, name := splitWildcard(part)
+		if name != "" && !isPrimitiveTypeName(name) && len(r.classNames[typeNameKey(name)]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the distinct unqualified names of the classes matching the key.
+func (r *TypeResolver) candidateNames(key string) []string {
+	names := make([]string, 0, 1)
+	for _, className := range r.classNames[key] {
+		if name := unqualifiedName(className); !utils.ContainsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isPrimitiveTypeName(typeName string) bool {
+	words := strings.Fields(typeName)
+	if len(words) != 1 {
+		return false
+	}
+	return utils.ContainsString(primitiveTypes, words[0])
+}
+
+// Returns the lower case type name without spaces, so "array list" and "ArrayList" have the same key.
+func typeNameKey(typeName string) string {
+	return strings.ToLower(strings.Join(strings.Fields(typeName), ""))
+}
+
+// Splits the type arguments enclosed in the brackets at the start of the string (like "< map < string , int > , ? >")
+// into the single type arguments ("map < string , int >" and "?").
+func typeArgumentsOf(str string) []string {
+	typeArguments := make([]string, 0, 2)
+	depth, start := 0, 1
+	for i, r := range str {
+		switch {
+		case string(r) == java.TypeArgumentsStart:
+			depth++
+		case string(r) == java.TypeArgumentsEnd:
+			depth--
+		case r == ',' && depth == 1:
+			typeArguments = appendTypeArgument(typeArguments, str[start:i])
+			start = i + 1
+		}
+		if depth == 0 {
+			return appendTypeArgument(typeArguments, str[start:i])
+		}
+	}
+	// the type arguments are not closed
+	return appendTypeArgument(typeArguments, str[start:])
+}
+
+func appendTypeArgument(typeArguments []string, typeArgument string) []string {
+	if typeArgument = strings.TrimSpace(typeArgument); typeArgument != "" {
+		return append(typeArguments, typeArgument)
+	}
+	return typeArguments
+}
+
+// Splits a type argument into the wildcard (like "? extends") and the bound type name. Both may be empty.
+func splitWildcard(typeArgument string) (string, string) {
+	words := strings.Fields(typeArgument)
+	if len(words) == 0 || words[0] != string(java.UnboundedWildcard) {
+		return "", strings.Join(words, " ")
+	} else if len(words) > 1 && (words[1] == string(java.ExtendsWildcard) || words[1] == string(java.SuperWildcard)) {
+		return strings.Join(words[:2], " "), strings.Join(words[2:], " ")
+	}
+	return words[0], strings.Join(words[1:], " ")
+}
+
+func isTypeArgumentDelimiter(r rune) bool {
+	return string(r) == java.TypeArgumentsStart || string(r) == java.TypeArgumentsEnd || r == ','
+}
+
+func unqualifiedName(className string) string {
+	splitted := strings.Split(className, ".")
+	return splitted[len(splitted)-1]
+}
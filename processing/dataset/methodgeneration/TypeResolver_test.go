@@ -0,0 +1,72 @@
+package methodgeneration
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/services/predictor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeResolutionRater(t *testing.T) {
+	// given
+	resolver := NewTypeResolver([]csv.Class{
+		{ClassName: "java.lang.String"},
+		{ClassName: "java.util.ArrayList"},
+		{ClassName: "com.example.Order"},
+		{ClassName: "com.example.Customer"},
+		{ClassName: "com.example.other.Invoice"},
+	})
+	rater := &TypeResolutionRater{resolver: resolver, unresolvedTypes: make(map[string]int)}
+	method := func(returnType string, parameters ...predictor.Parameter) Method {
+		return Method{
+			ClassName:    "com.example.Order",
+			ContextTypes: []string{"ArrayList"},
+			Method:       predictor.Method{Values: predictor.MethodValues{ReturnType: returnType, Parameters: parameters}},
+		}
+	}
+
+	// when
+	rater.Rate(method("array list", predictor.Parameter{Name: "name", Type: "string"}, predictor.Parameter{Name: "count", Type: "int"}))
+	rater.Rate(method("void", predictor.Parameter{Name: "customer", Type: "customer"}))
+	rater.Rate(method("strng", predictor.Parameter{Name: "invoice", Type: "invoice"}))
+	rater.Rate(method("void", predictor.Parameter{Name: "class", Type: "string"}, predictor.Parameter{Name: "name", Type: "string"}))
+	rater.Rate(method("boolean", predictor.Parameter{Name: "name", Type: "string"}, predictor.Parameter{Name: "name", Type: "string"}))
+
+	// then
+	assert.Equal(t, 5.0, rater.count)
+	assert.Equal(t, 4.0, rater.resolvedCount)
+	assert.Equal(t, 3.0, rater.validNamesCount)
+	assert.Equal(t, 2.0, rater.validCount)
+	assert.Equal(t, map[string]int{"Strng": 1, "Invoice": 1}, rater.unresolvedTypes)
+	assert.True(t, resolver.Exists("invoice"))
+	assert.False(t, resolver.Exists("strng"))
+}
+
+func TestResolveGenericTypes(t *testing.T) {
+	// given
+	resolver := NewTypeResolver([]csv.Class{
+		{ClassName: "java.lang.String"},
+		{ClassName: "java.lang.Number"},
+		{ClassName: "java.util.List"},
+		{ClassName: "java.util.Map"},
+		{ClassName: "com.example.Order"},
+	})
+	context := resolver.Context("com.example.Order", []string{"List", "Map"})
+
+	// when
+	list, isListResolved := resolver.Resolve("list < string >", context)
+	m, isMapResolved := resolver.Resolve("map < string , list < ? extends number > >", context)
+	unresolved, isUnresolvedResolved := resolver.Resolve("list < strng >", context)
+
+	// then
+	assert.True(t, isListResolved)
+	assert.Equal(t, "java.util.List<java.lang.String>", list)
+	assert.True(t, isMapResolved)
+	assert.Equal(t, "java.util.Map<java.lang.String, java.util.List<? extends java.lang.Number>>", m)
+	assert.False(t, isUnresolvedResolved)
+	assert.Equal(t, "java.util.List<Strng>", unresolved)
+	assert.True(t, resolver.Exists("List<? super String>"))
+	assert.False(t, resolver.Exists("List<Strng>"))
+}
@@ -18,10 +18,11 @@ func mapToMethods(rows []csv.MethodGenerationDatasetRow) ([]predictor.Method, er
 		}
 		output[i] = predictor.Method{
 			Context: predictor.MethodContext{
-				MethodName: method.MethodName,
-				ClassName:  strings.Split(method.ClassName, "."),
-				IsStatic:   method.IsStatic,
-				Types:      method.ContextTypes,
+				MethodName:    method.MethodName,
+				ClassName:     splitClassName(method.ClassName),
+				IsStatic:      method.IsStatic,
+				Types:         method.ContextTypes,
+				Documentation: mapToDocumentation(method),
 			},
 			Values: predictor.MethodValues{
 				ReturnType: method.ReturnType,
@@ -32,6 +33,39 @@ func mapToMethods(rows []csv.MethodGenerationDatasetRow) ([]predictor.Method, er
 	return output, nil
 }
 
+// Splits the qualified class name into it's parts. Returns an empty list for rows without class context.
+func splitClassName(className string) []string {
+	if className == "" {
+		return []string{}
+	}
+	return strings.Split(className, ".")
+}
+
+// Returns the documentation of the method or nil if the dataset row contains no javadoc.
+func mapToDocumentation(row csv.MethodGenerationDatasetRow) *predictor.Documentation {
+	if row.JavadocSummary == "" && csv.IsEmptyList(row.JavadocParameters) && row.JavadocReturn == "" {
+		return nil
+	}
+	documentation := &predictor.Documentation{
+		Summary: row.JavadocSummary,
+		Return:  row.JavadocReturn,
+	}
+	if !csv.IsEmptyList(row.JavadocParameters) {
+		documentation.Parameters = make([]predictor.ParameterDocumentation, len(row.JavadocParameters))
+		for i, parameter := range row.JavadocParameters {
+			name, description := parameter, ""
+			if index := strings.Index(parameter, " "); index >= 0 {
+				name, description = parameter[:index], parameter[index+1:]
+			}
+			documentation.Parameters[i] = predictor.ParameterDocumentation{
+				Name:        name,
+				Description: description,
+			}
+		}
+	}
+	return documentation
+}
+
 func mapToParameters(rawParameters []string) ([]predictor.Parameter, errors.Error) {
 	if csv.IsEmptyList(rawParameters) {
 		return nil, nil
@@ -2,6 +2,7 @@ package returntypesvalidation
 
 import (
 	"path/filepath"
+	"returntypes-langserver/common/code/java"
 	"returntypes-langserver/common/code/packagetree"
 	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/common/dataformat/csv"
@@ -25,6 +26,7 @@ type Processor struct {
 	typeClassMapper typeclasses.Mapper
 	typeLabelMapper *base.TypeLabelMapper
 	skip            bool
+	splitSizes      map[string]int
 }
 
 type ReturnTypes map[string]int
@@ -86,12 +88,12 @@ func (p *Processor) Process(method *csv.Method) (isFiltered bool, err errors.Err
 	if _, ok := p.methodsSet[identifier]; !ok {
 		p.methodsSet[identifier] = make(ReturnTypes)
 	}
-	p.methodsSet[identifier].Put(method.ReturnType)
+	p.methodsSet[identifier].Put(java.FormatTypeName(method.ReturnType, p.Options.TypeRendering, nil))
 	return false, nil
 }
 
 func (p *Processor) mapTypeToTypeClasses(method *csv.Method) errors.Error {
-	if returnType, err := p.typeClassMapper.MapReturnTypeToTypeClass(method.ReturnType, method.Labels); err != nil {
+	if returnType, err := p.typeClassMapper.MapReturnTypeToTypeClass(java.EraseTypeArguments(method.ReturnType), method.Labels); err != nil {
 		return err
 	} else {
 		method.ReturnType = returnType
@@ -128,9 +130,17 @@ func (p *Processor) Close() errors.Error {
 	} else if err := csv.NewFileWriter(p.labelFilePath()).WriteTypeLabelRecords(p.typeLabelMapper.GetMappings()); err != nil {
 		return err
 	}
+	p.splitSizes = map[string]int{
+		TrainingSetFileName:   len(trainingSet),
+		EvaluationSetFileName: len(evaluationSet),
+	}
 	return nil
 }
 
+func (p *Processor) SplitSizes() map[string]int {
+	return p.splitSizes
+}
+
 func (p *Processor) trainingFilePath() string {
 	return filepath.Join(p.OutputDir, TrainingSetFileName)
 }
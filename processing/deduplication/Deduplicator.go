@@ -0,0 +1,180 @@
+package deduplication
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/utils/progressbar"
+	"returntypes-langserver/processing/projects"
+	"sort"
+)
+
+const (
+	// The number of hash functions used for the MinHash signatures
+	HashCount = 64
+	// The number of bands used for locality sensitive hashing. Methods sharing at least one band are compared.
+	BandCount = 16
+)
+
+// Label for methods which are not inside of any configured project
+const UnknownProject = "(unknown)"
+
+type Options struct {
+	// The minimum estimated similarity of two methods to consider them as near-duplicates
+	Threshold float64
+}
+
+type Report struct {
+	// Method counts per project, sorted by the project name
+	Projects []ProjectReport
+	// The removed methods in the order of their occurence
+	Removed []RemovedMethod
+}
+
+type ProjectReport struct {
+	Project      string
+	MethodCount  int
+	RemovedCount int
+}
+
+type RemovedMethod struct {
+	Method  csv.Method
+	Project string
+	// The method which is kept instead of the removed method
+	Representative        csv.Method
+	RepresentativeProject string
+	Similarity            float64
+}
+
+type deduplicator struct {
+	options    Options
+	projects   []projects.Project
+	minHash    *MinHash
+	buckets    []map[string][]int
+	signatures map[int][]uint64
+	// The locations of the kept methods. Methods at the same location (like overloads) are no near-duplicates.
+	locations map[int]location
+}
+
+type location struct {
+	project  string
+	filePath string
+}
+
+// Removes near-duplicate methods. Of each group of near-duplicates, the first method is kept. Returns the kept methods
+// in their original order and a report of the removed methods.
+func Deduplicate(methods []csv.Method, projectList []projects.Project, options Options) ([]csv.Method, Report) {
+	d := &deduplicator{
+		options:    options,
+		projects:   projectList,
+		minHash:    NewMinHash(HashCount),
+		buckets:    make([]map[string][]int, BandCount),
+		signatures: make(map[int][]uint64),
+		locations:  make(map[int]location),
+	}
+	for i := range d.buckets {
+		d.buckets[i] = make(map[string][]int)
+	}
+	return d.deduplicate(methods)
+}
+
+func (d *deduplicator) deduplicate(methods []csv.Method) ([]csv.Method, Report) {
+	progress := progressbar.StartNew(len(methods))
+	progress.SetOperation("Remove near-duplicates")
+	defer progress.Finish()
+
+	kept := make([]csv.Method, 0, len(methods))
+	report := Report{}
+	projectReports := make(map[string]*ProjectReport)
+	projectOfMethod := make([]string, len(methods))
+	for i, method := range methods {
+		progress.Increment()
+
+		project, relativePath := d.findProject(&method)
+		projectOfMethod[i] = project
+		if _, ok := projectReports[project]; !ok {
+			projectReports[project] = &ProjectReport{Project: project}
+		}
+		projectReports[project].MethodCount++
+
+		methodLocation := location{project: project, filePath: filepath.ToSlash(relativePath)}
+		signature := d.minHash.Signature(Fingerprint(method, relativePath))
+		if representative, similarity, ok := d.findRepresentative(signature, methodLocation); ok {
+			projectReports[project].RemovedCount++
+			report.Removed = append(report.Removed, RemovedMethod{
+				Method:                method,
+				Project:               project,
+				Representative:        methods[representative],
+				RepresentativeProject: projectOfMethod[representative],
+				Similarity:            similarity,
+			})
+			continue
+		}
+		d.register(i, signature, methodLocation)
+		kept = append(kept, method)
+	}
+
+	for _, projectReport := range projectReports {
+		report.Projects = append(report.Projects, *projectReport)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		return report.Projects[i].Project < report.Projects[j].Project
+	})
+	return kept, report
+}
+
+// Returns the project name of the method and its file path relative to the project directory.
+func (d *deduplicator) findProject(method *csv.Method) (string, string) {
+	if method.Project != "" {
+		return method.Project, method.FilePath
+	}
+	// Methods extracted by older versions have no project, which can only be found for absolute file paths
+	if filepath.IsAbs(method.FilePath) {
+		for _, project := range d.projects {
+			if project.ContainsFile(method.FilePath) {
+				relativePath, _ := filepath.Rel(project.ExpectedDirectoryPath(), method.FilePath)
+				return project.Name(), relativePath
+			}
+		}
+	}
+	return UnknownProject, method.FilePath
+}
+
+// Searches for a kept method sharing a band with the signature which is similar enough and is not located in the same
+// file. Returns the index of the most similar method found.
+func (d *deduplicator) findRepresentative(signature []uint64, methodLocation location) (index int, similarity float64, ok bool) {
+	for band := range d.buckets {
+		for _, candidate := range d.buckets[band][d.bandKey(signature, band)] {
+			if d.locations[candidate] == methodLocation {
+				continue
+			} else if candidateSimilarity := Similarity(signature, d.signatures[candidate]); candidateSimilarity >= d.options.Threshold && candidateSimilarity > similarity {
+				index, similarity, ok = candidate, candidateSimilarity, true
+			}
+		}
+	}
+	return
+}
+
+// Registers the signature of a kept method in the buckets of each band.
+func (d *deduplicator) register(index int, signature []uint64, methodLocation location) {
+	d.signatures[index] = signature
+	d.locations[index] = methodLocation
+	for band := range d.buckets {
+		key := d.bandKey(signature, band)
+		d.buckets[band][key] = append(d.buckets[band][key], index)
+	}
+}
+
+func (d *deduplicator) bandKey(signature []uint64, band int) string {
+	rows := len(signature) / len(d.buckets)
+	key := make([]byte, rows*8)
+	for i := 0; i < rows; i++ {
+		binary.LittleEndian.PutUint64(key[i*8:], signature[band*rows+i])
+	}
+	return string(key)
+}
+
+// Returns the number of removed methods.
+func (r Report) RemovedCount() int {
+	return len(r.Removed)
+}
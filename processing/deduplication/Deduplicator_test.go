@@ -0,0 +1,102 @@
+package deduplication
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/dataformat/csv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicate(t *testing.T) {
+	// given
+	methods := []csv.Method{
+		{ClassName: "Parser", MethodName: "parse", ReturnType: "java.util.List<String>", Parameters: []string{"String input"}, FilePath: "/a/src/main/java/org/json/Parser.java"},
+		{ClassName: "Parser", MethodName: "parse", ReturnType: "java.util.List<String>", Parameters: []string{"String input"}, FilePath: "/fork/src/main/java/org/json/Parser.java"},
+		{ClassName: "Writer", MethodName: "write", ReturnType: "void", Parameters: []string{"String output"}, FilePath: "/a/src/main/java/org/json/Writer.java"},
+	}
+
+	// when
+	kept, report := Deduplicate(methods, nil, Options{Threshold: 0.8})
+
+	// then
+	assert.Len(t, kept, 2)
+	assert.Equal(t, "write", kept[1].MethodName)
+	assert.Equal(t, 1, report.RemovedCount())
+	assert.Equal(t, methods[1].FilePath, report.Removed[0].Method.FilePath)
+	assert.Equal(t, methods[0].FilePath, report.Removed[0].Representative.FilePath)
+	assert.Equal(t, []ProjectReport{{Project: UnknownProject, MethodCount: 3, RemovedCount: 1}}, report.Projects)
+}
+
+func TestDeduplicateReportsProjectsOfRelativeFilePaths(t *testing.T) {
+	// given
+	methods := []csv.Method{
+		{ClassName: "Parser", MethodName: "parse", ReturnType: "java.util.List<String>", Parameters: []string{"String input"}, FilePath: "src/main/java/org/json/Parser.java", Project: "json"},
+		{ClassName: "Parser", MethodName: "parse", ReturnType: "java.util.List<String>", Parameters: []string{"String input"}, FilePath: "src/main/java/org/json/Parser.java", Project: "json-fork"},
+		{ClassName: "Writer", MethodName: "write", ReturnType: "void", Parameters: []string{"String output"}, FilePath: "src/main/java/org/json/Writer.java", Project: "json"},
+		{ClassName: "Reader", MethodName: "read", ReturnType: "int", Parameters: []string{"char[] buffer"}, FilePath: "Reader.java"},
+	}
+
+	// when
+	kept, report := Deduplicate(methods, nil, Options{Threshold: 0.8})
+
+	// then
+	assert.Len(t, kept, 3)
+	assert.Equal(t, 1, report.RemovedCount())
+	assert.Equal(t, "json-fork", report.Removed[0].Project)
+	assert.Equal(t, "json", report.Removed[0].RepresentativeProject)
+	assert.Equal(t, []ProjectReport{
+		{Project: UnknownProject, MethodCount: 1, RemovedCount: 0},
+		{Project: "json", MethodCount: 2, RemovedCount: 0},
+		{Project: "json-fork", MethodCount: 1, RemovedCount: 1},
+	}, report.Projects)
+}
+
+func TestDeduplicateKeepsOverloads(t *testing.T) {
+	// given
+	filePath := "src/main/java/org/json/Builder.java"
+	methods := make([]csv.Method, 0)
+	for _, project := range []string{"json", "json-fork"} {
+		for _, parameterType := range []string{"boolean", "char", "int", "long", "float", "double", "String", "Object"} {
+			methods = append(methods, csv.Method{ClassName: "org.json.Builder", MethodName: "append", ReturnType: "org.json.Builder", Parameters: []string{parameterType + "/value"}, FilePath: filePath, Project: project})
+		}
+		methods = append(methods,
+			csv.Method{ClassName: "org.json.Builder", MethodName: "appendAll", ReturnType: "void", Parameters: []string{"java.util.List<java.lang.String>/values"}, FilePath: filePath, Project: project},
+			csv.Method{ClassName: "org.json.Builder", MethodName: "appendAll", ReturnType: "void", Parameters: []string{"java.util.List<java.lang.Integer>/values"}, FilePath: filePath, Project: project})
+	}
+
+	// when
+	kept, report := Deduplicate(methods, nil, Options{Threshold: 0.5})
+
+	// then
+	assert.Len(t, kept, 10)
+	for _, method := range kept {
+		assert.Equal(t, "json", method.Project)
+	}
+	assert.Equal(t, 10, report.RemovedCount())
+	for i, removed := range report.Removed {
+		assert.Equal(t, methods[i].Parameters, removed.Representative.Parameters)
+	}
+}
+
+func TestSignatureFeaturesContainTypeArguments(t *testing.T) {
+	// given
+	method := csv.Method{ClassName: "org.json.Builder", MethodName: "appendAll", ReturnType: "java.util.Map<java.lang.String, int[]>[]", Parameters: []string{"java.util.List<java.lang.String>[]/values"}}
+
+	// when
+	features := signatureFeatures(method)
+
+	// then
+	assert.Equal(t, []string{"c:builder", "m:appendall", "r:map<string, int[]>[]", "a:list<string>[] values"}, features)
+}
+
+func TestMinHashSimilarity(t *testing.T) {
+	// given
+	minHash := NewMinHash(256)
+
+	// when
+	similarity := Similarity(minHash.Signature([]string{"a", "b", "c", "d"}), minHash.Signature([]string{"a", "b", "c", "e"}))
+
+	// then
+	assert.InDelta(t, 0.6, similarity, 0.15)
+}
@@ -0,0 +1,84 @@
+// The deduplication package removes near-duplicate methods of the corpus. Near-duplicates appear for example in forked
+// repositories, vendored copies of libraries or generated code and would otherwise leak into training and evaluation sets.
+// Each method is fingerprinted by it's normalized signature and the shape of it's file path. Similar fingerprints are
+// clustered using MinHash, so only one representative of each cluster is kept. Methods of the same file (like overloads)
+// are never clustered together.
+package deduplication
+
+import (
+	"fmt"
+	"path/filepath"
+	"returntypes-langserver/common/code/java"
+	"returntypes-langserver/common/configuration"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/utils"
+	"strings"
+)
+
+// Source roots which are removed from the file path, so the path shape starts at the package directories.
+var sourceRoots = []string{"src/main/java/", "src/test/java/", "src/"}
+
+// Creates the fingerprint of the method as a set of features. The file path should be relative to the project directory.
+func Fingerprint(method csv.Method, relativeFilePath string) []string {
+	features := make([]string, 0, 8)
+	features = append(features, signatureFeatures(method)...)
+	features = append(features, pathShapeFeatures(relativeFilePath)...)
+	return features
+}
+
+// Returns the features of the normalized signature. Types (including their type arguments) are unqualified, and all names are lower cased.
+func signatureFeatures(method csv.Method) []string {
+	features := []string{
+		"c:" + strings.ToLower(utils.GetStringExtension(method.ClassName, ".")),
+		"m:" + strings.ToLower(method.MethodName),
+		"r:" + normalizeTypeName(method.ReturnType),
+	}
+	if csv.IsEmptyList(method.Parameters) {
+		return features
+	}
+	if parameters, err := java.ParseParameterList(method.Parameters); err == nil {
+		for _, parameter := range parameters {
+			typeName := normalizeTypeName(parameter.Type.TypeName)
+			if parameter.Type.IsArrayType {
+				typeName += java.ArrayTypeExtension
+			}
+			features = append(features, fmt.Sprintf("a:%s %s", typeName, strings.ToLower(parameter.Name)))
+		}
+	}
+	return features
+}
+
+// Returns the lower cased type name, in which the type and it's type arguments are unqualified (java.util.List<java.lang.String> -> list<string>).
+func normalizeTypeName(typeName string) string {
+	javaType := java.ParseTypeName(typeName)
+	normalized := javaType.Format(configuration.FullGenericTypes, func(t *java.Type) string {
+		return utils.GetStringExtension(t.TypeName, ".")
+	})
+	if javaType.IsArrayType {
+		normalized += java.ArrayTypeExtension
+	}
+	return strings.ToLower(normalized)
+}
+
+// Returns the features of the file path shape, which are the directories (from the source root on) and the file name.
+func pathShapeFeatures(relativeFilePath string) []string {
+	path := filepath.ToSlash(relativeFilePath)
+	for _, root := range sourceRoots {
+		if index := strings.LastIndex(path, root); index >= 0 {
+			path = path[index+len(root):]
+			break
+		}
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	features := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		} else if i == len(segments)-1 {
+			features = append(features, "f:"+strings.ToLower(segment))
+		} else {
+			features = append(features, "p:"+strings.ToLower(segment))
+		}
+	}
+	return features
+}
@@ -0,0 +1,64 @@
+package deduplication
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// Computes MinHash signatures of feature sets. The similarity of two signatures estimates the jaccard index of the feature sets.
+type MinHash struct {
+	seeds []uint64
+}
+
+// Creates a MinHash with the given number of hash functions. The seeds are deterministic, so signatures are reproducible.
+func NewMinHash(hashCount int) *MinHash {
+	seeds := make([]uint64, hashCount)
+	state := uint64(0x9E3779B97F4A7C15)
+	for i := range seeds {
+		state = splitMix64(state)
+		seeds[i] = state
+	}
+	return &MinHash{
+		seeds: seeds,
+	}
+}
+
+// Returns the signature of the feature set, containing the minimum hash value of the features for each hash function.
+func (m *MinHash) Signature(features []string) []uint64 {
+	signature := make([]uint64, len(m.seeds))
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for _, feature := range features {
+		hash := fnv.New64a()
+		hash.Write([]byte(feature))
+		featureHash := hash.Sum64()
+		for i, seed := range m.seeds {
+			if value := splitMix64(featureHash ^ seed); value < signature[i] {
+				signature[i] = value
+			}
+		}
+	}
+	return signature
+}
+
+// Returns the estimated jaccard index of the feature sets of both signatures.
+func Similarity(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	matches := 0
+	for i := range a {
+		if a[i] == b[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(a))
+}
+
+func splitMix64(x uint64) uint64 {
+	x += 0x9E3779B97F4A7C15
+	x = (x ^ (x >> 30)) * 0xBF58476D1CE4E5B9
+	x = (x ^ (x >> 27)) * 0x94D049BB133111EB
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,112 @@
+package dependencies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePom(t *testing.T) {
+	// given
+	pom := `<project>
+	<groupId>com.example</groupId>
+	<artifactId>example</artifactId>
+	<version>1.2.0</version>
+	<properties>
+		<guava.version>31.1-jre</guava.version>
+	</properties>
+	<dependencyManagement>
+		<dependencies>
+			<dependency>
+				<groupId>org.slf4j</groupId>
+				<artifactId>slf4j-api</artifactId>
+				<version>1.7.36</version>
+			</dependency>
+		</dependencies>
+	</dependencyManagement>
+	<dependencies>
+		<dependency>
+			<groupId>com.google.guava</groupId>
+			<artifactId>guava</artifactId>
+			<version>${guava.version}</version>
+		</dependency>
+		<dependency>
+			<groupId>org.slf4j</groupId>
+			<artifactId>slf4j-api</artifactId>
+		</dependency>
+		<dependency>
+			<groupId>com.example</groupId>
+			<artifactId>example-core</artifactId>
+			<version>${project.version}</version>
+			<optional>true</optional>
+		</dependency>
+		<dependency>
+			<groupId>junit</groupId>
+			<artifactId>junit</artifactId>
+			<version>4.13.2</version>
+			<scope>test</scope>
+		</dependency>
+	</dependencies>
+</project>`
+
+	// when
+	all, err := ParsePom([]byte(pom), false)
+	inherited, err2 := ParsePom([]byte(pom), true)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, err2)
+	assert.Equal(t, []Dependency{
+		{GroupId: "com.google.guava", ArtifactId: "guava", Version: "31.1-jre"},
+		{GroupId: "org.slf4j", ArtifactId: "slf4j-api", Version: "1.7.36"},
+		{GroupId: "com.example", ArtifactId: "example-core", Version: "1.2.0"},
+		{GroupId: "junit", ArtifactId: "junit", Version: "4.13.2"},
+	}, all)
+	assert.Equal(t, all[:2], inherited)
+}
+
+func TestParseGradleBuildFile(t *testing.T) {
+	// given
+	buildFile := `
+def junitVersion = '5.8.2'
+
+dependencies {
+	implementation 'com.google.guava:guava:31.1-jre'
+	api("org.apache.commons:commons-lang3:3.12.0")
+	testImplementation "org.junit.jupiter:junit-jupiter-api:${junitVersion}"
+	compileOnly group: 'org.projectlombok', name: 'lombok', version: '1.18.24'
+	annotationProcessor 'org.projectlombok:lombok:1.18.24'
+}`
+
+	// when
+	dependencies := ParseGradleBuildFile([]byte(buildFile), nil)
+
+	// then
+	assert.Equal(t, []Dependency{
+		{GroupId: "com.google.guava", ArtifactId: "guava", Version: "31.1-jre"},
+		{GroupId: "org.apache.commons", ArtifactId: "commons-lang3", Version: "3.12.0"},
+		{GroupId: "org.junit.jupiter", ArtifactId: "junit-jupiter-api", Version: "5.8.2"},
+		{GroupId: "org.projectlombok", ArtifactId: "lombok", Version: "1.18.24"},
+	}, dependencies)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.True(t, CompareVersions("1.10.0", "1.9.2") > 0)
+	assert.True(t, CompareVersions("2.0", "2.0.1") < 0)
+	assert.True(t, CompareVersions("1.0.1", "1.0-beta") > 0)
+	assert.Equal(t, 0, CompareVersions("3.12.0", "3.12.0"))
+}
+
+func TestCompareVersionsWithQualifiers(t *testing.T) {
+	assert.True(t, CompareVersions("1.0-SNAPSHOT", "1.0") < 0)
+	assert.True(t, CompareVersions("1.0", "1.0-SNAPSHOT") > 0)
+	assert.True(t, CompareVersions("1.0.1-SNAPSHOT", "1.0") > 0)
+	assert.True(t, CompareVersions("2.0-RC1", "2.0") < 0)
+	assert.True(t, CompareVersions("2.0-RC1", "2.0-RC2") < 0)
+	assert.True(t, CompareVersions("2.0-RC2", "2.0-SNAPSHOT") < 0)
+	assert.True(t, CompareVersions("2.0-beta", "2.0-RC1") < 0)
+	assert.Equal(t, 0, CompareVersions("5.4.2.Final", "5.4.2"))
+	assert.True(t, CompareVersions("5.4.2.Final", "5.4.3.Final") < 0)
+	assert.True(t, CompareVersions("5.4.2.Final", "5.4.2-SNAPSHOT") > 0)
+	assert.Equal(t, 0, CompareVersions("1.0", "1"))
+}
@@ -0,0 +1,41 @@
+// The dependencies package reads the dependency declarations of the build files (pom.xml, build.gradle) of java projects
+// and loads the classes of these dependencies from the jar files in a local maven repository. No network access is required,
+// so dependencies which are not available in the local repository are skipped.
+package dependencies
+
+import (
+	"fmt"
+	"strings"
+)
+
+const DependenciesErrorTitle = "Dependencies Error"
+
+// A dependency declared in a build file.
+type Dependency struct {
+	GroupId    string
+	ArtifactId string
+	// The declared version. Might be empty or contain unresolvable placeholders/ranges. In this case, the latest
+	// version available in the local repository is used.
+	Version string
+}
+
+// Returns the identifier of the artifact without version (group:artifact)
+func (d Dependency) Key() string {
+	return fmt.Sprintf("%s:%s", d.GroupId, d.ArtifactId)
+}
+
+func (d Dependency) String() string {
+	if d.Version == "" {
+		return d.Key()
+	}
+	return fmt.Sprintf("%s:%s", d.Key(), d.Version)
+}
+
+// Returns true if the version is a concrete version (not empty and without placeholders, ranges or dynamic parts)
+func (d Dependency) HasConcreteVersion() bool {
+	return d.Version != "" && !strings.ContainsAny(d.Version, "$[]()+,") && !strings.Contains(d.Version, "latest")
+}
+
+func (d Dependency) isValid() bool {
+	return d.GroupId != "" && d.ArtifactId != "" && !strings.ContainsAny(d.GroupId+d.ArtifactId, "${}")
+}
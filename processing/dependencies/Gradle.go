@@ -0,0 +1,105 @@
+package dependencies
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+)
+
+const (
+	GradleBuildFileName       = "build.gradle"
+	GradleKotlinBuildFileName = "build.gradle.kts"
+	GradlePropertiesFileName  = "gradle.properties"
+)
+
+// The gradle configurations whose dependencies may be referenced in the project's source code.
+const gradleConfigurations = `implementation|api|compile|compileOnly|compileOnlyApi|runtimeOnly|runtime|provided|` +
+	`testImplementation|testCompile|testCompileOnly|testRuntimeOnly|testApi`
+
+// Matches string notations like: implementation 'group:artifact:version' or implementation("group:artifact:version")
+var gradleStringNotationPattern = regexp.MustCompile(`(?m)^\s*(?:` + gradleConfigurations + `)\s*\(?\s*['"]([^'":\s]+):([^'":\s]+)(?::([^'":@\s]+))?[^'"]*['"]`)
+
+// Matches map notations like: implementation group: 'group', name: 'artifact', version: 'version'
+var gradleMapNotationPattern = regexp.MustCompile(`(?m)^\s*(?:` + gradleConfigurations + `)\s*\(?\s*group\s*[:=]\s*['"]([^'"]+)['"]\s*,\s*name\s*[:=]\s*['"]([^'"]+)['"](?:\s*,\s*version\s*[:=]\s*['"]([^'"]+)['"])?`)
+
+// Matches simple variable definitions like: def junitVersion = '4.13' or ext.junitVersion = "4.13"
+var gradleVariablePattern = regexp.MustCompile(`(?m)^\s*(?:def\s+|val\s+|var\s+|ext\.|set\(\s*['"])?([\w.]+)['"]?\s*[=,]\s*['"]([^'"$]+)['"]`)
+
+var gradleVariableReferencePattern = regexp.MustCompile(`\$\{?([\w.]+)\}?`)
+
+// Reads the dependencies declared in the gradle build file at the given path. Variables used in the version are
+// resolved by simple definitions inside the build file or by the gradle.properties file in the same directory.
+func ReadGradleBuildFile(path string) ([]Dependency, errors.Error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, DependenciesErrorTitle, "Could not read gradle build file at "+path)
+	}
+
+	variables := make(map[string]string)
+	if propertiesPath := filepath.Join(filepath.Dir(path), GradlePropertiesFileName); utils.FileExists(propertiesPath) {
+		if err := readGradleProperties(propertiesPath, variables); err != nil {
+			return nil, err
+		}
+	}
+	return ParseGradleBuildFile(contents, variables), nil
+}
+
+// Parses the dependencies declared in the gradle build file contents. The passed variables are used to resolve versions
+// and are extended by the variables defined in the build file.
+func ParseGradleBuildFile(contents []byte, variables map[string]string) []Dependency {
+	if variables == nil {
+		variables = make(map[string]string)
+	}
+	source := string(contents)
+	for _, match := range gradleVariablePattern.FindAllStringSubmatch(source, -1) {
+		variables[strings.TrimPrefix(match[1], "ext.")] = match[2]
+	}
+
+	dependencies := make([]Dependency, 0)
+	for _, pattern := range []*regexp.Regexp{gradleStringNotationPattern, gradleMapNotationPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(source, -1) {
+			dependency := Dependency{
+				GroupId:    match[1],
+				ArtifactId: match[2],
+				Version:    resolveGradleVariables(match[3], variables),
+			}
+			if dependency.isValid() {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+	return dependencies
+}
+
+func resolveGradleVariables(value string, variables map[string]string) string {
+	return gradleVariableReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		name := strings.Trim(reference, "${}")
+		if variable, ok := variables[name]; ok {
+			return variable
+		} else if variable, ok := variables[strings.TrimPrefix(name, "project.")]; ok {
+			return variable
+		}
+		return reference
+	})
+}
+
+func readGradleProperties(path string, variables map[string]string) errors.Error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, DependenciesErrorTitle, "Could not read gradle properties at "+path)
+	}
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := utils.KeyValueByEqualSign(line); ok {
+			variables[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return nil
+}
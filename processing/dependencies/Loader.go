@@ -0,0 +1,139 @@
+package dependencies
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"returntypes-langserver/common/code/java"
+	"returntypes-langserver/common/code/java/classfile"
+	"returntypes-langserver/common/code/packagetree"
+	"returntypes-langserver/common/dataformat/csv"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/common/utils/counter"
+)
+
+// Counts the dependencies which could not be found in the local repository
+const MissingDependencyCounter = "dependenciesMissingDependencyCounter"
+
+// Counts the classes loaded from dependencies
+const LoadedClassesCounter = "dependenciesLoadedClassesCounter"
+
+// Loads the classes of the dependencies declared in the project directories.
+type Loader struct {
+	Repository MavenRepository
+	// If true, dependencies declared in the pom files of the dependencies are also loaded.
+	Transitive bool
+	loaded     map[string]bool
+}
+
+func NewLoader(repositoryDir string, transitive bool) *Loader {
+	return &Loader{
+		Repository: MavenRepository{Dir: repositoryDir},
+		Transitive: transitive,
+		loaded:     make(map[string]bool),
+	}
+}
+
+// Finds the build files (pom.xml, build.gradle, build.gradle.kts) inside the project directory (including sub modules)
+// and returns the declared dependencies.
+func FindDeclaredDependencies(projectDir string) ([]Dependency, errors.Error) {
+	dependencies := make([]Dependency, 0)
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() {
+			if path != projectDir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules" || d.Name() == "target" || d.Name() == "build") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var declared []Dependency
+		var readErr errors.Error
+		switch d.Name() {
+		case PomFileName:
+			declared, readErr = ReadPomFile(path, false)
+		case GradleBuildFileName, GradleKotlinBuildFileName:
+			declared, readErr = ReadGradleBuildFile(path)
+		default:
+			return nil
+		}
+		if readErr != nil {
+			log.ReportProblemWithError(readErr, "Could not read dependencies of build file %s", path)
+			return nil
+		}
+		dependencies = append(dependencies, declared...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, DependenciesErrorTitle, "Could not search for build files in "+projectDir)
+	}
+	return dependencies, nil
+}
+
+// Loads the classes of the passed dependencies from the local repository. Each artifact is only loaded once, even
+// if it is passed in multiple calls.
+func (l *Loader) LoadClasses(dependencies []Dependency) []csv.Class {
+	classes := make([]csv.Class, 0)
+	for _, dependency := range dependencies {
+		classes = append(classes, l.loadDependency(dependency)...)
+	}
+	return classes
+}
+
+func (l *Loader) loadDependency(dependency Dependency) []csv.Class {
+	if l.loaded[dependency.Key()] {
+		return nil
+	}
+	l.loaded[dependency.Key()] = true
+
+	version, ok := l.Repository.AvailableVersion(dependency)
+	if !ok {
+		counter.For(MissingDependencyCounter).CountUp()
+		log.Info("Dependency %s is not available in the local repository\n", dependency)
+		return nil
+	}
+
+	headers, err := classfile.ReadJar(l.Repository.JarPath(dependency, version))
+	if err != nil {
+		log.ReportProblemWithError(err, "Could not load classes of dependency %s", dependency)
+		return nil
+	}
+	classes := classfile.ToClassRecords(headers)
+
+	if l.Transitive {
+		if inherited, err := ReadPomFile(l.Repository.PomPath(dependency, version), true); err == nil {
+			classes = append(classes, l.LoadClasses(inherited)...)
+		}
+	}
+	return classes
+}
+
+// Adds the classes to the package tree. Classes which already exist in the tree (e.g. because they are part of the
+// crawled projects or the default libraries) are skipped.
+func AddClassesToTree(tree *packagetree.Tree, classes []csv.Class) {
+	missing := make([]csv.Class, 0, len(classes))
+	for _, class := range classes {
+		if selector := tree.Select(class.ClassName); !selector.Exists() {
+			missing = append(missing, class)
+			counter.For(LoadedClassesCounter).CountUp()
+		}
+	}
+	java.FillPackageTreeByCsvClassNodes(tree, missing)
+}
+
+// Loads the dependencies declared in the build files of the project directories into the package tree.
+func LoadDependenciesToTree(tree *packagetree.Tree, projectDirs []string, repositoryDir string, transitive bool) {
+	loader := NewLoader(repositoryDir, transitive)
+	classes := make([]csv.Class, 0)
+	for _, dir := range projectDirs {
+		if declared, err := FindDeclaredDependencies(dir); err != nil {
+			log.ReportProblemWithError(err, "Could not load dependencies of project at %s", dir)
+		} else {
+			classes = append(classes, loader.LoadClasses(declared)...)
+		}
+	}
+	AddClassesToTree(tree, classes)
+}
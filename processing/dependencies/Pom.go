@@ -0,0 +1,159 @@
+package dependencies
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"returntypes-langserver/common/debug/errors"
+)
+
+const PomFileName = "pom.xml"
+
+type pomFile struct {
+	XMLName              xml.Name        `xml:"project"`
+	GroupId              string          `xml:"groupId"`
+	ArtifactId           string          `xml:"artifactId"`
+	Version              string          `xml:"version"`
+	Parent               pomParent       `xml:"parent"`
+	Properties           pomProperties   `xml:"properties"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type pomParent struct {
+	GroupId    string `xml:"groupId"`
+	ArtifactId string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+type pomDependency struct {
+	GroupId    string `xml:"groupId"`
+	ArtifactId string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+	Type       string `xml:"type"`
+	Optional   string `xml:"optional"`
+}
+
+// The properties of a pom file, where each element name is the property key and the element's text is it's value.
+type pomProperties map[string]string
+
+func (p *pomProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = make(pomProperties)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &element); err != nil {
+				return err
+			}
+			(*p)[element.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+var propertyPlaceholderPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// Reads the dependencies declared in the pom file at the given path. If onlyInherited is true, only dependencies
+// which would be inherited transitively are returned (compile/runtime scope and not optional). Otherwise, all
+// dependencies except imported boms are returned.
+func ReadPomFile(path string, onlyInherited bool) ([]Dependency, errors.Error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, DependenciesErrorTitle, "Could not read pom file at "+path)
+	}
+	return ParsePom(contents, onlyInherited)
+}
+
+// Parses the dependencies declared in the pom file contents.
+func ParsePom(contents []byte, onlyInherited bool) ([]Dependency, errors.Error) {
+	var pom pomFile
+	if err := xml.Unmarshal(contents, &pom); err != nil {
+		return nil, errors.Wrap(err, DependenciesErrorTitle, "Could not parse pom file")
+	}
+
+	managedVersions := make(map[string]string)
+	for _, managed := range pom.DependencyManagement {
+		dependency := pom.toDependency(managed)
+		managedVersions[dependency.Key()] = dependency.Version
+	}
+
+	dependencies := make([]Dependency, 0, len(pom.Dependencies))
+	for _, declared := range pom.Dependencies {
+		if !isIncludedScope(declared, onlyInherited) {
+			continue
+		}
+		dependency := pom.toDependency(declared)
+		if dependency.Version == "" {
+			dependency.Version = managedVersions[dependency.Key()]
+		}
+		if dependency.isValid() {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies, nil
+}
+
+func isIncludedScope(dependency pomDependency, onlyInherited bool) bool {
+	if dependency.Scope == "import" || (dependency.Type != "" && dependency.Type != "jar") {
+		return false
+	} else if !onlyInherited {
+		return true
+	}
+	return (dependency.Scope == "" || dependency.Scope == "compile" || dependency.Scope == "runtime") &&
+		strings.TrimSpace(dependency.Optional) != "true"
+}
+
+func (pom *pomFile) toDependency(dependency pomDependency) Dependency {
+	return Dependency{
+		GroupId:    pom.resolveProperties(dependency.GroupId),
+		ArtifactId: pom.resolveProperties(dependency.ArtifactId),
+		Version:    pom.resolveProperties(dependency.Version),
+	}
+}
+
+// Replaces the property placeholders (like ${junit.version}) with the values defined in the pom. Unknown
+// placeholders stay unchanged.
+func (pom *pomFile) resolveProperties(value string) string {
+	value = strings.TrimSpace(value)
+	for i := 0; i < 5 && strings.Contains(value, "${"); i++ {
+		value = propertyPlaceholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+			if property, ok := pom.property(placeholder[2 : len(placeholder)-1]); ok {
+				return property
+			}
+			return placeholder
+		})
+	}
+	return value
+}
+
+func (pom *pomFile) property(name string) (string, bool) {
+	switch name {
+	case "project.version", "pom.version", "version":
+		if pom.Version != "" {
+			return pom.Version, true
+		}
+		return pom.Parent.Version, pom.Parent.Version != ""
+	case "project.groupId", "pom.groupId", "groupId":
+		if pom.GroupId != "" {
+			return pom.GroupId, true
+		}
+		return pom.Parent.GroupId, pom.Parent.GroupId != ""
+	case "project.artifactId", "pom.artifactId", "artifactId":
+		return pom.ArtifactId, pom.ArtifactId != ""
+	case "project.parent.version", "parent.version":
+		return pom.Parent.Version, pom.Parent.Version != ""
+	case "project.parent.groupId", "parent.groupId":
+		return pom.Parent.GroupId, pom.Parent.GroupId != ""
+	}
+	value, ok := pom.Properties[name]
+	return value, ok
+}
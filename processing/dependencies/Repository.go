@@ -0,0 +1,147 @@
+package dependencies
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"returntypes-langserver/common/utils"
+)
+
+// A local maven repository (like ~/.m2/repository) with the layout <group path>/<artifact>/<version>/<artifact>-<version>.jar
+type MavenRepository struct {
+	Dir string
+}
+
+// Returns the version of the dependency which is available in the repository. If the declared version is not available
+// (or not concrete), the latest version available in the repository is used. Returns false if no version is available.
+func (r MavenRepository) AvailableVersion(dependency Dependency) (string, bool) {
+	if dependency.HasConcreteVersion() && utils.FileExists(r.artifactPath(dependency, dependency.Version, "jar")) {
+		return dependency.Version, true
+	}
+
+	entries, err := ioutil.ReadDir(r.artifactDir(dependency))
+	if err != nil {
+		return "", false
+	}
+	latest := ""
+	for _, entry := range entries {
+		if entry.IsDir() && utils.FileExists(r.artifactPath(dependency, entry.Name(), "jar")) {
+			if latest == "" || CompareVersions(entry.Name(), latest) > 0 {
+				latest = entry.Name()
+			}
+		}
+	}
+	return latest, latest != ""
+}
+
+// Returns the path to the jar file of the dependency in the given version.
+func (r MavenRepository) JarPath(dependency Dependency, version string) string {
+	return r.artifactPath(dependency, version, "jar")
+}
+
+// Returns the path to the pom file of the dependency in the given version.
+func (r MavenRepository) PomPath(dependency Dependency, version string) string {
+	return r.artifactPath(dependency, version, "pom")
+}
+
+func (r MavenRepository) artifactDir(dependency Dependency) string {
+	groupPath := filepath.FromSlash(strings.ReplaceAll(dependency.GroupId, ".", "/"))
+	return filepath.Join(r.Dir, groupPath, dependency.ArtifactId)
+}
+
+func (r MavenRepository) artifactPath(dependency Dependency, version, extension string) string {
+	return filepath.Join(r.artifactDir(dependency), version, fmt.Sprintf("%s-%s.%s", dependency.ArtifactId, version, extension))
+}
+
+// Splits versions at separators and at transitions between digits and letters (like 1.0-RC1 -> 1, 0, RC, 1)
+var versionPartPattern = regexp.MustCompile(`[0-9]+|[^0-9.\-_]+`)
+
+// The order of the known qualifiers following the order of maven. Releases have an empty qualifier, so all qualifiers
+// before it are pre-releases. Unknown qualifiers are newer than all known qualifiers.
+var qualifierOrder = map[string]int{
+	"alpha":     0,
+	"beta":      1,
+	"milestone": 2,
+	"rc":        3,
+	"snapshot":  4,
+	"":          5,
+	"sp":        6,
+}
+
+// Alternative names of the known qualifiers
+var qualifierAliases = map[string]string{
+	"a":       "alpha",
+	"b":       "beta",
+	"m":       "milestone",
+	"cr":      "rc",
+	"ga":      "",
+	"final":   "",
+	"release": "",
+}
+
+// Compares two version strings part by part similar to maven. Numeric parts are compared numerically and are newer
+// than qualifiers. Qualifiers are compared by their known order (like 1.0-alpha < 1.0-RC1 < 1.0-SNAPSHOT < 1.0 = 1.0.Final)
+// and lexicographically otherwise. Missing parts are treated as 0 or as a release, so 1.0 equals 1.
+// Returns a negative number if a < b, a positive number if a > b and zero if they are equal.
+func CompareVersions(a, b string) int {
+	partsA, partsB := splitVersion(a), splitVersion(b)
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		partA, partB := "", ""
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+		if cmp := compareVersionParts(partA, partB); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+func splitVersion(version string) []string {
+	parts := versionPartPattern.FindAllString(strings.ToLower(version), -1)
+	for i, part := range parts {
+		if alias, ok := qualifierAliases[part]; ok {
+			parts[i] = alias
+		}
+	}
+	return parts
+}
+
+// Compares two parts of a version. An empty part is a missing part, which is equal to 0 or a release.
+func compareVersionParts(a, b string) int {
+	numberA, errA := strconv.Atoi(a)
+	numberB, errB := strconv.Atoi(b)
+	if a == "" && errB == nil {
+		numberA, errA = 0, nil
+	} else if b == "" && errA == nil {
+		numberB, errB = 0, nil
+	}
+
+	if errA == nil && errB == nil {
+		return numberA - numberB
+	} else if errA == nil {
+		// numeric parts are considered newer than qualifiers (like 1.0.1 > 1.0-beta)
+		return 1
+	} else if errB == nil {
+		return -1
+	}
+	orderA, isKnownA := qualifierOrder[a]
+	orderB, isKnownB := qualifierOrder[b]
+	if !isKnownA {
+		orderA = len(qualifierOrder)
+	}
+	if !isKnownB {
+		orderB = len(qualifierOrder)
+	}
+	if orderA != orderB {
+		return orderA - orderB
+	}
+	return strings.Compare(a, b)
+}
@@ -112,8 +112,10 @@ func unqualifyTypeNames(method csv.Method) csv.Method {
 }
 
 func unqualifyTypeName(typeName string) string {
-	parts := strings.Split(typeName, ".")
-	return parts[len(parts)-1]
+	return java.FormatTypeName(typeName, configuration.FullGenericTypes, func(name string) string {
+		parts := strings.Split(name, ".")
+		return parts[len(parts)-1]
+	})
 }
 
 func addProjectColumn(record []string) []string {
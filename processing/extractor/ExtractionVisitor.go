@@ -3,6 +3,7 @@ package extractor
 import (
 	"returntypes-langserver/common/code/java"
 	"returntypes-langserver/common/code/packagetree"
+	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/common/dataformat/csv"
 	"returntypes-langserver/common/utils"
 	"strings"
@@ -23,6 +24,8 @@ type ExtractionVisitor struct {
 	currentFile *java.CodeFile
 	// The currently visited class
 	currentClass *java.Class
+	// The name of the project the visited code files belong to
+	projectName string
 }
 
 func (visitor *ExtractionVisitor) VisitCodeFile(codeFile *java.CodeFile) {
@@ -90,23 +93,43 @@ func (visitor *ExtractionVisitor) resolve(typeName *java.Type) (string, bool) {
 
 // Writes methods with their return types into a csvfile
 func (visitor *ExtractionVisitor) VisitMethod(method *java.Method) {
-	resolvedReturnType, _ := visitor.resolve(&method.ReturnType)
+	resolvedReturnType, _ := java.ResolveWithTypeArguments(&method.ReturnType, visitor.packageTree)
+	javadoc := method.ParseJavadoc()
 	filePath := ""
 	if visitor.currentFile != nil {
 		filePath = visitor.currentFile.FilePath
 	}
 	visitor.methods = append(visitor.methods, csv.Method{
-		MethodName: method.MethodName,
-		ReturnType: resolvedReturnType,
-		Parameters: visitor.mapParameters(method.Parameters),
-		Labels:     java.GetMethodLabels(method),
-		FilePath:   filePath,
-		ClassName:  visitor.getQualifiedCurrentClassName(),
-		Modifier:   method.Modifier,
-		ClassField: visitor.findClassFieldMatchInName(method.MethodName),
+		MethodName:        method.MethodName,
+		ReturnType:        resolvedReturnType,
+		Parameters:        visitor.mapParameters(method.Parameters),
+		Labels:            java.GetMethodLabels(method),
+		FilePath:          filePath,
+		ClassName:         visitor.getQualifiedCurrentClassName(),
+		Modifier:          method.Modifier,
+		ClassField:        visitor.findClassFieldMatchInName(method.MethodName),
+		JavadocSummary:    javadoc.Summary,
+		JavadocParameters: visitor.mapJavadocParameters(javadoc.Parameters),
+		JavadocReturn:     javadoc.Return,
+		Project:           visitor.projectName,
 	})
 }
 
+// Maps the documented parameters in this format: "<name> <description>"
+// List seperators in the description are replaced, so the list can be splitted again.
+func (visitor *ExtractionVisitor) mapJavadocParameters(parameters []java.JavadocParameter) []string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	seperator := configuration.CsvListSeperator()
+	output := make([]string, len(parameters))
+	for i, parameter := range parameters {
+		description := strings.ReplaceAll(parameter.Description, seperator, ";")
+		output[i] = strings.TrimSpace(parameter.Name + " " + description)
+	}
+	return output
+}
+
 // Searches for the longest match of a class field name in the given name. The match is case insensitive.
 // Returns an empty string if no match is found
 func (visitor *ExtractionVisitor) findClassFieldMatchInName(name string) string {
@@ -126,15 +149,11 @@ func (visitor *ExtractionVisitor) findClassFieldMatchInName(name string) string
 }
 
 // Maps parameters in this format: "<type> <method>"
+// The parameter types and their type arguments are resolved to their canonical names.
 func (visitor *ExtractionVisitor) mapParameters(parameters []java.Parameter) []string {
 	return java.FormatParameterList(parameters, func(p java.Parameter) (typ, name string) {
-		if len(p.Type.TypeName) == 1 {
-			typeName, isResolved := java.Resolve(&p.Type, visitor.packageTree)
-			if isResolved {
-				return utils.GetStringExtension(typeName, "."), p.Name
-			}
-		}
-		return p.Type.TypeName, p.Name
+		resolvedTypeName, _ := java.ResolveWithTypeArguments(&p.Type, visitor.packageTree)
+		return resolvedTypeName, p.Name
 	})
 }
 
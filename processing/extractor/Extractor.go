@@ -10,8 +10,10 @@ import (
 	"returntypes-langserver/common/debug/log"
 	"returntypes-langserver/common/utils"
 	"returntypes-langserver/common/utils/progressbar"
+	"returntypes-langserver/processing/dependencies"
 	"returntypes-langserver/processing/projects"
 	"returntypes-langserver/processing/statistics"
+	"returntypes-langserver/services/crawler"
 )
 
 const ExtractorErrorTitle = "Extractor Error"
@@ -21,7 +23,10 @@ type Extractor struct {
 	OutputDir string
 	tree      packagetree.Tree
 	xmlroots  []java.FileContainer
-	err       errors.Error
+	// The name of the project of each xml root
+	projectNames []string
+	projects     []projects.Project
+	err          errors.Error
 }
 
 func (extractor *Extractor) Err() errors.Error {
@@ -42,6 +47,7 @@ func (extractor *Extractor) Run(inputFiles []string) {
 }
 
 func (extractor *Extractor) RunOnProjects(projects []projects.Project) {
+	extractor.projects = projects
 	extractor.Run(GetPreprocessedFilePathForProjects(projects))
 }
 
@@ -50,6 +56,24 @@ func (extractor *Extractor) createPackageTree(inputFiles []string) {
 	extractor.tree = packagetree.New()
 	java.LoadDefaultPackagesToTree(&extractor.tree)
 	extractor.loadJavaFilesFromXMLFiles(inputFiles)
+	extractor.loadDependenciesToTree()
+}
+
+// Loads the classes of the dependencies declared in the build files of the projects into the package tree.
+// This is done after loading the project files, so classes of the projects are preferred over classes of dependencies.
+func (extractor *Extractor) loadDependenciesToTree() {
+	if extractor.err != nil || !configuration.DependenciesResolve() || len(extractor.projects) == 0 {
+		return
+	}
+
+	log.Info("Load classes of project dependencies from %s...\n", configuration.DependenciesMavenRepository())
+	projectDirs := make([]string, 0, len(extractor.projects))
+	for _, project := range extractor.projects {
+		if utils.DirExists(project.ExpectedDirectoryPath()) {
+			projectDirs = append(projectDirs, project.ExpectedDirectoryPath())
+		}
+	}
+	dependencies.LoadDependenciesToTree(&extractor.tree, projectDirs, configuration.DependenciesMavenRepository(), configuration.DependenciesTransitive())
 }
 
 // Looks for the extracted code files in the input directory, unmarshals them and inserts the files into the package tree
@@ -59,6 +83,7 @@ func (extractor *Extractor) loadJavaFilesFromXMLFiles(inputFiles []string) {
 	}
 
 	extractor.xmlroots = make([]java.FileContainer, 0, len(inputFiles))
+	extractor.projectNames = make([]string, 0, len(inputFiles))
 
 	progress := progressbar.StartNew(len(inputFiles))
 	progress.SetOperation("Read entries")
@@ -83,6 +108,7 @@ func (extractor *Extractor) loadJavaFilesFromXMLFiles(inputFiles []string) {
 
 		extractor.loadFilesToPackageTree(xmlroot)
 		extractor.xmlroots = append(extractor.xmlroots, xmlroot)
+		extractor.projectNames = append(extractor.projectNames, getProjectNameOfPreprocessedFile(path))
 	}
 	return
 }
@@ -111,6 +137,18 @@ func (extractor *Extractor) extract() {
 		return
 	}
 
+	methodRecords, classRecords, fileRecords := extractor.extractRecords()
+	if err := csv.NewFileWriter(configuration.ClassHierarchyOutputPath()).WriteClassRecords(classRecords); err != nil {
+		extractor.err = err
+	} else if err := csv.NewFileWriter(configuration.MethodsWithReturnTypesOutputPath()).WriteMethodRecords(methodRecords); err != nil {
+		extractor.err = err
+	} else if err := csv.NewFileWriter(configuration.FileContextTypesOutputPath()).WriteFileContextTypesRecords(fileRecords); err != nil {
+		extractor.err = err
+	}
+}
+
+// Visits the code files of the loaded java elements and returns the records of their methods, classes and files
+func (extractor *Extractor) extractRecords() ([]csv.Method, []csv.Class, []csv.FileContextTypes) {
 	allFileCount := 0
 	for i := range extractor.xmlroots {
 		allFileCount += len(extractor.xmlroots[i].CodeFiles())
@@ -131,18 +169,40 @@ func (extractor *Extractor) extract() {
 				classes:     classRecords,
 				fileTypes:   fileRecords,
 				packageTree: &extractor.tree,
+				projectName: extractor.projectNames[i],
 			}
 			codeFile.Accept(&visitor)
 			methodRecords, classRecords, fileRecords = visitor.methods, visitor.classes, visitor.fileTypes
 		}
 	}
-	if err := csv.NewFileWriter(configuration.ClassHierarchyOutputPath()).WriteClassRecords(classRecords); err != nil {
-		extractor.err = err
-	} else if err := csv.NewFileWriter(configuration.MethodsWithReturnTypesOutputPath()).WriteMethodRecords(methodRecords); err != nil {
-		extractor.err = err
-	} else if err := csv.NewFileWriter(configuration.FileContextTypesOutputPath()).WriteFileContextTypesRecords(fileRecords); err != nil {
-		extractor.err = err
+	return methodRecords, classRecords, fileRecords
+}
+
+// Crawls the java files of the directory and extracts their methods, the context types of their files and the class
+// hierarchy of their classes. In contrast to Run, the records are not written to the output files, so the extracted
+// projects are not affected. The type names are resolved against the default packages and the files of the directory.
+func ExtractDirectory(path string) ([]csv.Method, []csv.FileContextTypes, []csv.Class, errors.Error) {
+	crawlerOptions := crawler.NewOptions().
+		Forced(!configuration.StrictMode()).
+		WithJavaVersion(configuration.CrawlerDefaultJavaVersion()).
+		Build()
+	xmlroot, err := crawler.GetCodeElementsOfDirectory(path, crawlerOptions)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	extractor := Extractor{
+		tree:         packagetree.New(),
+		xmlroots:     []java.FileContainer{xmlroot},
+		projectNames: []string{""},
+	}
+	java.LoadDefaultPackagesToTree(&extractor.tree)
+	extractor.loadFilesToPackageTree(xmlroot)
+	if extractor.err != nil {
+		return nil, nil, nil, extractor.err
 	}
+	methodRecords, classRecords, fileRecords := extractor.extractRecords()
+	return methodRecords, fileRecords, classRecords, nil
 }
 
 func (extractor *Extractor) writeCsvRecords(path string, records [][]string) {
@@ -10,15 +10,16 @@ import (
 	"returntypes-langserver/common/utils"
 	"returntypes-langserver/processing/projects"
 	"returntypes-langserver/services/crawler"
+	"strings"
 )
 
 // Preprocesses the java code for one project
 func PreprocessSourceCodeForProject(project projects.Project, previousState *projects.Project) bool {
 	// If an output file does already exist, skip preprocessing the data for this project.
-	if exists, err := preprocessedSourceCodeFileExists(project); err != nil {
+	if required, err := IsPreprocessingRequired(project, previousState); err != nil {
 		log.ReportProblemWithError(err, "Could not check if xml output file for %s exists", project.Name())
 		return false
-	} else if exists && !isRecrawlingRequired(project, previousState) {
+	} else if !required {
 		return false
 	}
 
@@ -39,6 +40,16 @@ func PreprocessSourceCodeForProject(project projects.Project, previousState *pro
 	return true
 }
 
+// Returns true if the crawler output file for the project does not exist or needs to be recreated.
+func IsPreprocessingRequired(project projects.Project, previousState *projects.Project) (bool, errors.Error) {
+	if exists, err := preprocessedSourceCodeFileExists(project); err != nil {
+		return false, err
+	} else if exists && !isRecrawlingRequired(project, previousState) {
+		return false, nil
+	}
+	return true, nil
+}
+
 func isRecrawlingRequired(project projects.Project, previousState *projects.Project) bool {
 	return previousState != nil && previousState.JavaVersion != project.JavaVersion
 }
@@ -89,3 +100,8 @@ func GetPreprocessedFilePathForProjects(projects []projects.Project) []string {
 func GetPreprocessedFilePathForProject(project projects.Project) string {
 	return filepath.Join(configuration.CrawlerOutputDir(), project.Name()+".xml")
 }
+
+// Returns the name of the project of a crawler output file created by PreprocessSourceCodeForProject.
+func getProjectNameOfPreprocessedFile(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), ".xml")
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"returntypes-langserver/common/debug/errors"
+
+	"github.com/go-git/go-git/v5"
 )
 
 // Loads the repositories to clone from the git input file and clones them to the project input dir (if not already exist)
@@ -20,7 +22,7 @@ func CloneRepositories(repositories []RepositoryDefinition) errors.Error {
 	}
 
 	for _, repository := range repositories {
-		if cloned, err := isAlreadyClonedRepository(repository.DirName); err != nil {
+		if cloned, err := IsAlreadyCloned(repository.DirName); err != nil {
 			log.ReportProblemWithError(err, "Skipped cloning %s because an error occured\n", repository.DirName)
 			continue
 		} else if cloned {
@@ -61,8 +63,8 @@ func createRepositoryOutputDir() errors.Error {
 	return nil
 }
 
-// checks if the repository is already cloned (inside the project input dir)
-func isAlreadyClonedRepository(repositoryName string) (bool, errors.Error) {
+// Checks if the repository is already cloned (inside the project input dir)
+func IsAlreadyCloned(repositoryName string) (bool, errors.Error) {
 	fileInfo, err := os.Stat(filepath.Join(configuration.ClonerOutputDir(), repositoryName))
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -88,3 +90,16 @@ func LoadRepositoryInfo(repositoryName string) ([]byte, errors.Error) {
 		return content, nil
 	}
 }
+
+// Returns the hash of the commit checked out in the repository under the given directory.
+func HeadCommit(repositoryDir string) (string, errors.Error) {
+	repository, err := git.PlainOpenWithOptions(repositoryDir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", errors.Wrap(err, CloneErrorTitle, "Could not open repository")
+	}
+	head, err := repository.Head()
+	if err != nil {
+		return "", errors.Wrap(err, CloneErrorTitle, "Could not resolve HEAD of repository")
+	}
+	return head.Hash().String(), nil
+}
@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/utils"
+)
+
+var ErrCheckpoint = errors.ErrorId("Pipeline", "Could not process checkpoint")
+
+// Marks a step as completed.
+type Checkpoint struct {
+	Step        string    `json:"step"`
+	Fingerprint string    `json:"fingerprint"`
+	CompletedAt time.Time `json:"completedAt"`
+	// A hash of the fingerprint and the state of the outputs when the step was completed. Steps depending on this step
+	// are only invalidated if it changes, so executing the step again with the same result does not invalidate them.
+	Result string `json:"result"`
+	// The results of the checkpoints of the dependencies when the step was executed
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+type CheckpointState string
+
+const (
+	// The step was not completed yet
+	CheckpointMissing CheckpointState = "missing"
+	// The step was completed, but it's inputs, outputs or dependencies have changed since
+	CheckpointInvalidated CheckpointState = "invalidated"
+	// The step was completed and is up to date
+	CheckpointComplete CheckpointState = "complete"
+)
+
+func checkpointPath(dir, step string) string {
+	return filepath.Join(dir, step+".json")
+}
+
+// Loads the checkpoint of the step. Returns nil if the step has no checkpoint.
+func LoadCheckpoint(dir, step string) (*Checkpoint, errors.Error) {
+	contents, err := ioutil.ReadFile(checkpointPath(dir, step))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, ErrCheckpoint.Wrap(err)
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(contents, &checkpoint); err != nil {
+		return nil, ErrCheckpoint.Wrap(err)
+	}
+	return &checkpoint, nil
+}
+
+func (c Checkpoint) Save(dir string) errors.Error {
+	file, err := utils.CreateFile(checkpointPath(dir, c.Step))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return ErrCheckpoint.Wrap(err)
+	}
+	return nil
+}
+
+// Removes the checkpoint of the step, so the step is executed on the next run.
+func RemoveCheckpoint(dir, step string) errors.Error {
+	if err := os.Remove(checkpointPath(dir, step)); err != nil && !os.IsNotExist(err) {
+		return ErrCheckpoint.Wrap(err)
+	}
+	return nil
+}
+
+// Returns the result of a step with the fingerprint and outputs, which changes if the fingerprint or the state of any
+// output changes.
+func resultOf(fingerprint string, outputs []string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", fingerprint, HashFileStates(outputs...))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Returns a hash of the existence, size and modification time of the files or directories. Unlike hashing the
+// contents, this is cheap enough for large files like the crawler outputs.
+func HashFileStates(paths ...string) string {
+	hash := sha256.New()
+	for _, path := range paths {
+		if info, err := os.Stat(path); err != nil {
+			fmt.Fprintf(hash, "%s:missing\n", path)
+		} else {
+			fmt.Fprintf(hash, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
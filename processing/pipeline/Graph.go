@@ -0,0 +1,272 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/common/debug/log"
+	"returntypes-langserver/common/utils"
+)
+
+type Status string
+
+const (
+	// The step was skipped because it's checkpoint is up to date
+	StatusSkipped Status = "skipped"
+	StatusRun     Status = "run"
+	StatusFailed  Status = "failed"
+	// The step was not executed because a dependency did not complete
+	StatusBlocked  Status = "blocked"
+	StatusDisabled Status = "disabled"
+)
+
+// The result of a step in a run of the graph.
+type Result struct {
+	Step   string
+	Status Status
+	// Why the step was executed, skipped or blocked
+	Reason   string
+	Duration time.Duration
+	Err      errors.Error
+}
+
+type Summary []Result
+
+// Returns the names of the failed steps.
+func (s Summary) Failed() []string {
+	failed := make([]string, 0)
+	for _, result := range s {
+		if result.Status == StatusFailed {
+			failed = append(failed, result.Step)
+		}
+	}
+	return failed
+}
+
+// Returns an error if any step has failed.
+func (s Summary) Err() errors.Error {
+	if failed := s.Failed(); len(failed) > 0 {
+		return errors.New("Pipeline", "The following steps have failed: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (s Summary) String() string {
+	builder := strings.Builder{}
+	tabWriter := tabwriter.NewWriter(&builder, 0, 4, 2, ' ', 0)
+	for _, result := range s {
+		reason := result.Reason
+		if result.Status == StatusRun {
+			reason = fmt.Sprintf("%s (%s)", reason, result.Duration.Round(time.Second))
+		} else if result.Status == StatusFailed && result.Err != nil {
+			// The full error is logged when the step fails
+			reason = strings.SplitN(result.Err.Error(), "\n", 2)[0]
+		}
+		fmt.Fprintf(tabWriter, "%s\t%s\t%s\n", result.Step, result.Status, reason)
+	}
+	tabWriter.Flush()
+	return builder.String()
+}
+
+// A graph of steps which are executed in the order of their dependencies.
+type Graph struct {
+	steps         []Step
+	checkpointDir string
+}
+
+// Creates a graph of the steps, which keeps the order of the steps as far as their dependencies allow. The checkpoints
+// of the steps are stored in the checkpoint directory.
+func NewGraph(checkpointDir string, steps []Step) (*Graph, errors.Error) {
+	names := make(utils.StringSet)
+	for _, step := range steps {
+		names.Put(step.Name)
+	}
+	for _, step := range steps {
+		for _, dependency := range step.DependsOn {
+			if !names.Has(dependency) {
+				return nil, errors.New("Pipeline", "The step %s depends on the unknown step %s", step.Name, dependency)
+			}
+		}
+	}
+
+	sorted := make([]Step, 0, len(steps))
+	completed := make(utils.StringSet)
+	for len(sorted) < len(steps) {
+		next, ok := nextStep(steps, completed)
+		if !ok {
+			return nil, errors.New("Pipeline", "The dependencies of the steps contain a cycle")
+		}
+		sorted = append(sorted, next)
+		completed.Put(next.Name)
+	}
+	return &Graph{
+		steps:         sorted,
+		checkpointDir: checkpointDir,
+	}, nil
+}
+
+// Returns the first step which is not completed and whose dependencies are completed.
+func nextStep(steps []Step, completed utils.StringSet) (Step, bool) {
+	for _, step := range steps {
+		if completed.Has(step.Name) {
+			continue
+		}
+		isReady := true
+		for _, dependency := range step.DependsOn {
+			if !completed.Has(dependency) {
+				isReady = false
+				break
+			}
+		}
+		if isReady {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// Returns the steps in the order they are executed.
+func (g *Graph) Steps() []Step {
+	return g.steps
+}
+
+func (g *Graph) Step(name string) (Step, bool) {
+	for _, step := range g.steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// Executes all steps which are not disabled and whose checkpoints are not up to date. Steps depending on a failed step
+// are not executed.
+func (g *Graph) Run() Summary {
+	summary := make(Summary, 0, len(g.steps))
+	statuses := make(map[string]Status)
+	for _, step := range g.steps {
+		result := g.runIfRequired(step, statuses)
+		statuses[step.Name] = result.Status
+		summary = append(summary, result)
+	}
+	return summary
+}
+
+func (g *Graph) runIfRequired(step Step, statuses map[string]Status) Result {
+	if step.IsDisabled() {
+		return Result{Step: step.Name, Status: StatusDisabled, Reason: "disabled by configuration"}
+	}
+	for _, dependency := range step.DependsOn {
+		if statuses[dependency] == StatusFailed || statuses[dependency] == StatusBlocked {
+			return Result{Step: step.Name, Status: StatusBlocked, Reason: fmt.Sprintf("the step %s did not complete", dependency)}
+		}
+	}
+	if step.IsForced() {
+		return g.execute(step, "forced by configuration")
+	}
+
+	state, reason, err := g.CheckpointState(step)
+	if err != nil {
+		return g.failed(step, err)
+	} else if state == CheckpointComplete {
+		return Result{Step: step.Name, Status: StatusSkipped, Reason: reason}
+	}
+	return g.execute(step, reason)
+}
+
+// Executes the step regardless of it's checkpoint and dependencies and updates the checkpoint.
+func (g *Graph) RunStep(name string) Result {
+	step, ok := g.Step(name)
+	if !ok {
+		return Result{Step: name, Status: StatusFailed, Err: errors.New("Pipeline", "There is no step named %s", name)}
+	}
+	return g.execute(step, "executed explicitly")
+}
+
+func (g *Graph) execute(step Step, reason string) Result {
+	// The checkpoint is removed first, so the step is not skipped on the next run if it fails
+	if err := RemoveCheckpoint(g.checkpointDir, step.Name); err != nil {
+		return g.failed(step, err)
+	}
+	fingerprint, err := step.Fingerprint()
+	if err != nil {
+		return g.failed(step, err)
+	}
+
+	log.Info("Execute step %s: %s\n", step.Name, reason)
+	start := time.Now()
+	if err := step.Run(); err != nil {
+		return g.failed(step, err)
+	}
+
+	dependencies, err := g.dependencyResults(step)
+	if err != nil {
+		return g.failed(step, err)
+	}
+	checkpoint := Checkpoint{
+		Step:         step.Name,
+		Fingerprint:  fingerprint,
+		CompletedAt:  time.Now(),
+		Result:       resultOf(fingerprint, step.Outputs()),
+		Dependencies: dependencies,
+	}
+	if err := checkpoint.Save(g.checkpointDir); err != nil {
+		return g.failed(step, err)
+	}
+	return Result{Step: step.Name, Status: StatusRun, Reason: reason, Duration: time.Since(start)}
+}
+
+func (g *Graph) failed(step Step, err errors.Error) Result {
+	log.Error(err)
+	return Result{Step: step.Name, Status: StatusFailed, Err: err}
+}
+
+// Returns the state of the step's checkpoint and the reason for it.
+func (g *Graph) CheckpointState(step Step) (CheckpointState, string, errors.Error) {
+	checkpoint, err := LoadCheckpoint(g.checkpointDir, step.Name)
+	if err != nil {
+		return "", "", err
+	} else if checkpoint == nil {
+		return CheckpointMissing, "not completed yet", nil
+	}
+
+	if fingerprint, err := step.Fingerprint(); err != nil {
+		return "", "", err
+	} else if fingerprint != checkpoint.Fingerprint {
+		return CheckpointInvalidated, "the inputs have changed", nil
+	}
+	for _, output := range step.Outputs() {
+		if !utils.FileExists(output) && !utils.DirExists(output) {
+			return CheckpointInvalidated, fmt.Sprintf("the output %s is missing", output), nil
+		}
+	}
+	dependencies, err := g.dependencyResults(step)
+	if err != nil {
+		return "", "", err
+	}
+	for _, dependency := range step.DependsOn {
+		if dependencies[dependency] != checkpoint.Dependencies[dependency] {
+			return CheckpointInvalidated, fmt.Sprintf("the result of step %s has changed", dependency), nil
+		}
+	}
+	return CheckpointComplete, fmt.Sprintf("completed at %s", checkpoint.CompletedAt.Format(time.RFC3339)), nil
+}
+
+// Returns the results of the dependencies' checkpoints. The result is empty for dependencies without checkpoint.
+func (g *Graph) dependencyResults(step Step) (map[string]string, errors.Error) {
+	results := make(map[string]string, len(step.DependsOn))
+	for _, dependency := range step.DependsOn {
+		checkpoint, err := LoadCheckpoint(g.checkpointDir, dependency)
+		if err != nil {
+			return nil, err
+		} else if checkpoint != nil {
+			results[dependency] = checkpoint.Result
+		} else {
+			results[dependency] = ""
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"returntypes-langserver/common/debug/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStep struct {
+	runs        int
+	fingerprint string
+	outputs     []string
+	err         errors.Error
+}
+
+func (s *testStep) toStep(name string, dependsOn ...string) Step {
+	return Step{
+		Name:      name,
+		DependsOn: dependsOn,
+		Run: func() errors.Error {
+			s.runs++
+			return s.err
+		},
+		Fingerprint: func() (string, errors.Error) {
+			return s.fingerprint, nil
+		},
+		Outputs: func() []string {
+			return s.outputs
+		},
+		IsDisabled: Never,
+		IsForced:   Never,
+	}
+}
+
+func statuses(summary Summary) []Status {
+	result := make([]Status, len(summary))
+	for i := range summary {
+		result[i] = summary[i].Status
+	}
+	return result
+}
+
+func TestNewGraphSortsByDependencies(t *testing.T) {
+	// given
+	a, b, c := &testStep{}, &testStep{}, &testStep{}
+
+	// when
+	graph, err := NewGraph(t.TempDir(), []Step{a.toStep("a", "c"), b.toStep("b"), c.toStep("c", "b")})
+
+	// then
+	assert.NoError(t, err)
+	names := make([]string, 0)
+	for _, step := range graph.Steps() {
+		names = append(names, step.Name)
+	}
+	assert.Equal(t, []string{"b", "c", "a"}, names)
+}
+
+func TestNewGraphFailsOnCycle(t *testing.T) {
+	// given
+	a, b := &testStep{}, &testStep{}
+
+	// when
+	_, err := NewGraph(t.TempDir(), []Step{a.toStep("a", "b"), b.toStep("b", "a")})
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestRunResumesAtFailedStep(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	a, b, c := &testStep{}, &testStep{err: errors.New("Test", "failed")}, &testStep{}
+	steps := []Step{a.toStep("a"), b.toStep("b", "a"), c.toStep("c", "b")}
+	graph, _ := NewGraph(dir, steps)
+	firstRun := graph.Run()
+	b.err = nil
+
+	// when
+	secondRun := graph.Run()
+
+	// then
+	assert.Equal(t, []Status{StatusRun, StatusFailed, StatusBlocked}, statuses(firstRun))
+	assert.Equal(t, []Status{StatusSkipped, StatusRun, StatusRun}, statuses(secondRun))
+	assert.Equal(t, 1, a.runs)
+	assert.Equal(t, 2, b.runs)
+	assert.Equal(t, 1, c.runs)
+}
+
+func TestRunInvalidatesDependentSteps(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	a, b, c := &testStep{}, &testStep{fingerprint: "1"}, &testStep{}
+	steps := []Step{a.toStep("a"), b.toStep("b", "a"), c.toStep("c", "b")}
+	graph, _ := NewGraph(dir, steps)
+	graph.Run()
+	b.fingerprint = "2"
+
+	// when
+	summary := graph.Run()
+
+	// then
+	assert.Equal(t, []Status{StatusSkipped, StatusRun, StatusRun}, statuses(summary))
+	assert.NoError(t, summary.Err())
+}
+
+func TestRunDoesNotInvalidateDependentStepsIfResultIsUnchanged(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	// The output of a is never written, so a is executed on each run
+	a, b, c := &testStep{outputs: []string{filepath.Join(dir, "missing")}}, &testStep{}, &testStep{}
+	steps := []Step{a.toStep("a"), b.toStep("b", "a"), c.toStep("c", "b")}
+	graph, _ := NewGraph(dir, steps)
+	graph.Run()
+
+	// when
+	summary := graph.Run()
+
+	// then
+	assert.Equal(t, []Status{StatusRun, StatusSkipped, StatusSkipped}, statuses(summary))
+	assert.Equal(t, 2, a.runs)
+	assert.Equal(t, 1, b.runs)
+	assert.Equal(t, 1, c.runs)
+}
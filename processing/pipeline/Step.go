@@ -0,0 +1,55 @@
+// The pipeline package executes the steps of the dataset creation process as a dependency graph. A checkpoint is
+// written for each completed step, so a rerun resumes from the first step which is incomplete or whose inputs changed.
+package pipeline
+
+import "returntypes-langserver/common/debug/errors"
+
+// A step of the dataset creation process.
+type Step struct {
+	Name string
+	// The names of the steps which need to be completed before this step
+	DependsOn []string
+	// Executes the step
+	Run func() errors.Error
+	// Returns the actions the step would perform without executing them
+	Plan func() ([]Action, errors.Error)
+	// Returns a hash of the inputs of the step (like input files and configuration values). If the hash changes, the
+	// checkpoint of the step is invalidated.
+	Fingerprint func() (string, errors.Error)
+	// Returns the paths of the files or directories written by the step. If any of them is missing, the checkpoint of
+	// the step is invalidated.
+	Outputs func() []string
+	// Returns true if the step is disabled by the configuration. Disabled steps are skipped, but do not block the steps
+	// depending on them.
+	IsDisabled func() bool
+	// Returns true if the step should be executed regardless of it's checkpoint
+	IsForced func() bool
+}
+
+// An action planned by a step.
+type Action struct {
+	Description string
+	// True if the action is not executed, mostly because it's output is already up to date
+	IsSkipped bool
+}
+
+// Returns the number of actions which are not skipped.
+func PendingActions(actions []Action) int {
+	count := 0
+	for _, action := range actions {
+		if !action.IsSkipped {
+			count++
+		}
+	}
+	return count
+}
+
+// May be used for IsDisabled or IsForced if the step is never disabled or forced.
+func Never() bool {
+	return false
+}
+
+// May be used for Outputs if the step writes no files which are checked.
+func NoOutputs() []string {
+	return nil
+}
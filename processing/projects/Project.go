@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/processing/git"
+	"strings"
 )
 
 type Project struct {
@@ -47,6 +48,12 @@ func (p Project) ExpectedDirectoryPath() string {
 	return filepath.Join(configuration.ClonerOutputDir(), p.Name())
 }
 
+// Returns true if the file path points to a file inside of the project directory.
+func (p Project) ContainsFile(filePath string) bool {
+	relativePath, err := filepath.Rel(p.ExpectedDirectoryPath(), filePath)
+	return err == nil && relativePath != ".." && !strings.HasPrefix(relativePath, ".."+string(filepath.Separator))
+}
+
 func (p Project) Name() string {
 	if p.AlternativeName != "" {
 		return p.AlternativeName
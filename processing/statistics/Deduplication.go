@@ -0,0 +1,49 @@
+package statistics
+
+import (
+	"returntypes-langserver/common/dataformat/excel"
+	"returntypes-langserver/common/debug/errors"
+	"returntypes-langserver/processing/deduplication"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Writes the number of removed near-duplicates per project and a list of all removed methods with their representatives.
+func CreateDeduplicationStatistics(report deduplication.Report, outputPath string) errors.Error {
+	file := excelize.NewFile()
+	defer file.Close()
+	file.Path = outputPath
+
+	projectsSheet := "Projects"
+	file.SetSheetName("Sheet1", projectsSheet)
+	file.SetColWidth(projectsSheet, excel.GetColumnIdentifier(0), excel.GetColumnIdentifier(0), 40)
+	cursor := excel.NewCursor(file, projectsSheet)
+	values := [][]interface{}{{"Project", "Methods", "Removed near-duplicates", "Removed (%)"}}
+	for _, project := range report.Projects {
+		values = append(values, []interface{}{project.Project, project.MethodCount, project.RemovedCount,
+			float64(project.RemovedCount) / float64(project.MethodCount) * 100})
+	}
+	if err := cursor.WriteValues(values); err != nil {
+		return err
+	}
+
+	removedSheet := "Removed methods"
+	file.NewSheet(removedSheet)
+	file.SetColWidth(removedSheet, excel.GetColumnIdentifier(0), excel.GetColumnIdentifier(3), 40)
+	cursor = excel.NewCursor(file, removedSheet)
+	values = [][]interface{}{{"Project", "Removed method", "Representative project", "Representative method", "Similarity"}}
+	for _, removed := range report.Removed {
+		values = append(values, []interface{}{removed.Project, formatMethod(removed.Method.ClassName, removed.Method.MethodName, removed.Method.FilePath),
+			removed.RepresentativeProject, formatMethod(removed.Representative.ClassName, removed.Representative.MethodName, removed.Representative.FilePath),
+			removed.Similarity})
+	}
+	if err := cursor.WriteValues(values); err != nil {
+		return err
+	}
+	return excel.SaveFile(file)
+}
+
+func formatMethod(className, methodName, filePath string) string {
+	return strings.Join([]string{className, methodName}, ".") + " (" + filePath + ")"
+}
@@ -3,6 +3,7 @@ package statistics
 import (
 	"fmt"
 	"returntypes-langserver/common/code/java"
+	"returntypes-langserver/common/configuration"
 	"returntypes-langserver/common/dataformat/csv"
 	"returntypes-langserver/common/dataformat/excel"
 	"returntypes-langserver/common/debug/errors"
@@ -26,16 +27,13 @@ func CreateStatisticsForMethods(methods []csv.Method, outputPath string) errors.
 	for _, method := range methods {
 		progress.Increment()
 
-		pars, err := java.ParseParameterList(method.Parameters)
+		outputTokens, err := OutputSequenceTokens(method)
 		if err != nil {
 			return err
 		}
-		outputSequence := getOutputSequence(pars, method.ReturnType)
-		outputTokens := metrics.TokenizeSentence(predictor.SplitMethodNameToSentence(outputSequence))
 		outputSequenceTokens.Add(outputTokens)
 
-		inputSequence := getInputSequence(method)
-		inputTokens := strings.Split(predictor.SplitMethodNameToSentence(inputSequence), " ")
+		inputTokens := InputSequenceTokens(method)
 		inputSequenceTokens.Add(inputTokens)
 
 		fullTokens := make([]string, len(inputTokens)+len(outputTokens))
@@ -87,6 +85,21 @@ func getAverage(tokenCount TokenCount) float64 {
 	return average / rowCount
 }
 
+// Returns the tokens of the output sequence (parameters and return type) of the method.
+func OutputSequenceTokens(method csv.Method) ([]string, errors.Error) {
+	parameters, err := java.ParseParameterList(method.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	outputSequence := getOutputSequence(parameters, method.ReturnType)
+	return metrics.TokenizeSentence(predictor.SplitMethodNameToSentence(outputSequence)), nil
+}
+
+// Returns the tokens of the input sequence (class and method name) of the method.
+func InputSequenceTokens(method csv.Method) []string {
+	return strings.Split(predictor.SplitMethodNameToSentence(getInputSequence(method)), " ")
+}
+
 func getInputSequence(method csv.Method) string {
 	s := ""
 	if utils.ContainsString(method.Modifier, "static") {
@@ -95,14 +108,15 @@ func getInputSequence(method csv.Method) string {
 	return fmt.Sprintf("%s%s %s", s, method.ClassName, method.MethodName)
 }
 
+// Returns the output sequence of the method with the type names rendered like in datasets with erased types.
 func getOutputSequence(parameters []java.Parameter, returnType string) string {
-	returnType = utils.GetStringExtension(returnType, ".")
+	returnType = java.FormatSimpleTypeName(returnType, configuration.ErasedTypes)
 	output := ""
 	for i, par := range parameters {
 		if i > 0 {
 			output += " [psp] "
 		}
-		typeName := par.Type.TypeName
+		typeName := java.FormatSimpleTypeName(par.Type.TypeName, configuration.ErasedTypes)
 		if par.Type.IsArrayType {
 			typeName += " [arr]"
 		}
@@ -0,0 +1,31 @@
+package statistics
+
+import (
+	"testing"
+
+	"returntypes-langserver/common/dataformat/csv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputSequenceTokensUseRenderedTypeNames(t *testing.T) {
+	// given
+	method := csv.Method{
+		ReturnType: "java.util.List<java.lang.String>",
+		Parameters: []string{"java.util.Map<java.lang.String, java.lang.Integer>/counts", "java.lang.String[]/names"},
+	}
+	renderedMethod := csv.Method{
+		ReturnType: "List",
+		Parameters: []string{"Map/counts", "String[]/names"},
+	}
+
+	// when
+	tokens, err := OutputSequenceTokens(method)
+	renderedTokens, renderedErr := OutputSequenceTokens(renderedMethod)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, renderedErr)
+	assert.Equal(t, renderedTokens, tokens)
+	assert.NotContains(t, tokens, "java")
+}
@@ -17,6 +17,19 @@ type MethodContext struct {
 	ClassName  []string `json:"className"`
 	IsStatic   bool     `json:"isStatic"`
 	Types      []string `json:"types"`
+	// Optional documentation of the method, which is only set if the dataset uses javadoc
+	Documentation *Documentation `json:"documentation,omitempty"`
+}
+
+type Documentation struct {
+	Summary    string                   `json:"summary,omitempty"`
+	Parameters []ParameterDocumentation `json:"parameters,omitempty"`
+	Return     string                   `json:"return,omitempty"`
+}
+
+type ParameterDocumentation struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
 
 func (m MethodContext) String() string {